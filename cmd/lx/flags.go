@@ -0,0 +1,160 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kalayciburak/lx/internal/input"
+	"github.com/kalayciburak/lx/internal/logx"
+	"github.com/kalayciburak/lx/internal/signal"
+	"github.com/kalayciburak/lx/internal/ui"
+)
+
+type levelAliasFlag struct{}
+
+func (levelAliasFlag) String() string {
+	return ""
+}
+
+func (levelAliasFlag) Set(value string) error {
+	alias, name, ok := strings.Cut(value, "=")
+	if !ok || strings.TrimSpace(alias) == "" {
+		return fmt.Errorf("expected alias=LEVEL, got %q", value)
+	}
+	level, ok := logx.ParseLevel(name)
+	if !ok {
+		return fmt.Errorf("unknown level %q", name)
+	}
+	logx.SetLevelAlias(alias, level)
+	return nil
+}
+
+type listFlag struct {
+	list *[]string
+}
+
+func (f listFlag) String() string {
+	if f.list == nil {
+		return ""
+	}
+	return strings.Join(*f.list, ",")
+}
+
+func (f listFlag) Set(value string) error {
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			*f.list = append(*f.list, part)
+		}
+	}
+	return nil
+}
+
+type burstFlag struct {
+	windows *[]signal.BurstWindow
+}
+
+func (f burstFlag) String() string {
+	if f.windows == nil {
+		return ""
+	}
+	parts := make([]string, len(*f.windows))
+	for i, w := range *f.windows {
+		parts[i] = strconv.Itoa(w.Seconds) + ":" + strconv.Itoa(w.Threshold)
+	}
+	return strings.Join(parts, ",")
+}
+
+func (f burstFlag) Set(value string) error {
+	for _, part := range strings.Split(value, ",") {
+		secs, count, ok := strings.Cut(strings.TrimSpace(part), ":")
+		s, err1 := strconv.Atoi(secs)
+		c, err2 := strconv.Atoi(count)
+		if !ok || err1 != nil || err2 != nil || s <= 0 || c <= 0 {
+			return fmt.Errorf("expected SECONDS:COUNT, got %q", part)
+		}
+		*f.windows = append(*f.windows, signal.BurstWindow{Seconds: s, Threshold: c})
+	}
+	return nil
+}
+
+type options struct {
+	args     []string
+	fields   logx.FieldMapping
+	theme    string
+	bursts   []signal.BurstWindow
+	baseline string
+	before   *int
+	after    *int
+	context  *int
+}
+
+func splitCommand(args []string) (flags, command []string) {
+	for i, arg := range args {
+		if arg == "--" {
+			return args[:i], args[i+1:]
+		}
+	}
+	return args, nil
+}
+
+func parseFlags(args []string) (*options, error) {
+	opts := &options{}
+	fs := flag.NewFlagSet("lx", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: lx [flags] [file]")
+		fmt.Fprintln(fs.Output(), "       lx listen [--udp addr] [--tcp addr] [--http addr]")
+		fmt.Fprintln(fs.Output(), "       lx [flags] -- command [args...]")
+		fmt.Fprintln(fs.Output(), "       lx config check [file...]")
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&opts.theme, "theme", "", "color theme: "+strings.Join(ui.ThemeNames(), ", "))
+	fs.StringVar(&opts.baseline, "baseline", "", "known-good log opened as a baseline workspace for compare (B)")
+	fs.Var(levelAliasFlag{}, "level-alias", "map a custom level name to a standard level, e.g. sev5=critical (repeatable)")
+	fs.Var(listFlag{&opts.fields.Message}, "message-field", "JSON key or dotted path holding the message, e.g. event (repeatable)")
+	fs.Var(listFlag{&opts.fields.Level}, "level-field", "JSON key or dotted path holding the level, e.g. log.level (repeatable)")
+	fs.Var(listFlag{&opts.fields.Timestamp}, "timestamp-field", "JSON key or dotted path holding the timestamp, e.g. eventTime (repeatable)")
+	before := fs.Int("B", 0, "show N lines before each filter match")
+	after := fs.Int("A", 0, "show N lines after each filter match")
+	context := fs.Int("C", 0, "show N lines before and after each filter match")
+	fs.Var(burstFlag{&opts.bursts}, "burst", "burst window as SECONDS:COUNT, e.g. 10:5,60:15 (repeatable, replaces the configured windows)")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "B":
+			opts.before = before
+		case "A":
+			opts.after = after
+		case "C":
+			opts.context = context
+		}
+	})
+	opts.args = fs.Args()
+	return opts, nil
+}
+
+func parseListenFlags(args []string) (input.ListenConfig, error) {
+	var cfg input.ListenConfig
+	fs := flag.NewFlagSet("lx listen", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: lx listen [--udp addr] [--tcp addr] [--http addr]")
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&cfg.UDP, "udp", "", "accept syslog datagrams on addr, e.g. :5514 (localhost unless a host is given)")
+	fs.StringVar(&cfg.TCP, "tcp", "", "accept newline-delimited log streams on addr")
+	fs.StringVar(&cfg.HTTP, "http", "", "accept POSTed NDJSON or OTLP/JSON on addr, e.g. :4318")
+
+	if err := fs.Parse(args); err != nil {
+		return cfg, err
+	}
+	if cfg.IsEmpty() {
+		fmt.Fprintln(fs.Output(), "lx listen: at least one of --udp, --tcp or --http is required")
+		fs.Usage()
+		return cfg, fmt.Errorf("no listener configured")
+	}
+	return cfg, nil
+}
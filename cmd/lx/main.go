@@ -1,21 +1,79 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/kalayciburak/lx/internal/app"
+	"github.com/kalayciburak/lx/internal/config"
 	"github.com/kalayciburak/lx/internal/input"
 	"github.com/kalayciburak/lx/internal/logx"
+	"github.com/kalayciburak/lx/internal/signal"
 	"github.com/kalayciburak/lx/internal/ui"
 )
 
-const asyncLoadingThreshold = 5000
+var asyncLoadingThreshold = 5000
+
+var baseline *app.State
 
 func main() {
-	source, err := input.Detect(os.Args[1:])
+	args, command := splitCommand(os.Args[1:])
+	opts, err := parseFlags(args)
+	if err != nil {
+		if err == flag.ErrHelp {
+			os.Exit(0)
+		}
+		os.Exit(2)
+	}
+	args = opts.args
+
+	if len(args) > 0 && args[0] == "config" {
+		os.Exit(runConfig(args[1:]))
+	}
+
+	if err := applyConfig(opts); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+
+	if opts.baseline != "" {
+		lines, err := input.ReadFile(opts.baseline)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		baseline = app.NewState(logx.ParseLines(lines), input.ModeFile, opts.baseline)
+	}
+
+	if len(command) > 0 {
+		runCommand(command)
+		os.Exit(0)
+	}
+
+	if len(args) > 0 && args[0] == "listen" {
+		cfg, err := parseListenFlags(args[1:])
+		if err != nil {
+			if err == flag.ErrHelp {
+				os.Exit(0)
+			}
+			os.Exit(2)
+		}
+		lineCh := make(chan string, 1000)
+		listener, err := input.Listen(cfg, lineCh)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		runLive(input.ModeListen, cfg.String(), lineCh, listener.Errors())
+		listener.Close()
+		os.Exit(0)
+	}
+
+	source, err := input.Detect(args)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
@@ -29,65 +87,34 @@ func main() {
 	}
 
 	if source != nil && source.IsLive {
-		state := app.NewLoadingState(inputMode, fileName)
-		state.IsLive = true
-		state.IsLoading = false
-		model := ui.NewModel(state)
-		p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion(), tea.WithInputTTY())
-
 		lineCh := make(chan string, 1000)
-		go input.StreamStdin(lineCh)
-
-		go func() {
-			var batch []string
-			ticker := time.NewTicker(100 * time.Millisecond)
-			defer ticker.Stop()
-
-			for {
-				select {
-				case line, ok := <-lineCh:
-					if !ok {
-						if len(batch) > 0 {
-							p.Send(ui.LiveBatchMsg{Entries: logx.ParseLines(batch)})
-						}
-						p.Send(ui.LiveStoppedMsg{})
-						return
-					}
-					batch = append(batch, line)
-					if len(batch) >= 100 {
-						p.Send(ui.LiveBatchMsg{Entries: logx.ParseLines(batch)})
-						batch = nil
-					}
-				case <-ticker.C:
-					if len(batch) > 0 {
-						p.Send(ui.LiveBatchMsg{Entries: logx.ParseLines(batch)})
-						batch = nil
-					}
-				}
-			}
-		}()
-
-		p.Run()
+		errCh := make(chan error, 1)
+		go input.StreamStdin(lineCh, errCh)
+		runLive(inputMode, fileName, lineCh, errCh, tea.WithInputTTY())
 		os.Exit(0)
 	}
 
 	if source != nil && len(source.Content) > asyncLoadingThreshold {
 		state := app.NewLoadingState(inputMode, fileName)
-		model := ui.NewModel(state)
+		model := newModel(state)
 		p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
 
 		go func() {
 			lines := source.Content
 			batchSize := ui.LoadingBatchSize
+			parser := logx.NewParser()
 
 			for i := 0; i < len(lines); i += batchSize {
 				end := i + batchSize
 				if end > len(lines) {
 					end = len(lines)
 				}
-				batch := logx.ParseLines(lines[i:end])
+				batch := parser.Parse(lines[i:end])
 				p.Send(ui.LoadingBatchMsg{Entries: batch})
 			}
+			if rest := parser.Flush(); len(rest) > 0 {
+				p.Send(ui.LoadingBatchMsg{Entries: rest})
+			}
 			p.Send(ui.LoadingCompleteMsg{})
 		}()
 
@@ -104,7 +131,7 @@ func main() {
 	}
 
 	state := app.NewState(entries, inputMode, fileName)
-	model := ui.NewModel(state)
+	model := newModel(state)
 
 	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
 	if _, err := p.Run(); err != nil {
@@ -112,3 +139,201 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+func newModel(state *app.State) ui.Model {
+	model := ui.NewModel(state)
+	if baseline != nil {
+		model = model.WithBaseline(baseline)
+	}
+	return model
+}
+
+func applyConfig(opts *options) error {
+	dir, err := os.Getwd()
+	if err != nil {
+		dir = "."
+	}
+	cfg, err := config.LoadLayered(dir)
+	if err != nil {
+		return err
+	}
+
+	fields := opts.fields
+	fields.Message = append(fields.Message, cfg.Fields.Message...)
+	fields.Level = append(fields.Level, cfg.Fields.Level...)
+	fields.Timestamp = append(fields.Timestamp, cfg.Fields.Timestamp...)
+	if !fields.IsEmpty() {
+		logx.SetFieldMapping(fields)
+	}
+	if opts.theme != "" {
+		cfg.ThemeName = opts.theme
+	}
+	if len(opts.bursts) > 0 {
+		cfg.BurstWindows = opts.bursts
+	}
+	for _, n := range []*int{opts.before, opts.after, opts.context} {
+		if n != nil && *n < 0 {
+			return fmt.Errorf("-A, -B and -C must not be negative")
+		}
+	}
+	if opts.context != nil {
+		cfg.ContextBefore, cfg.ContextAfter = opts.context, opts.context
+	}
+	if opts.before != nil {
+		cfg.ContextBefore = opts.before
+	}
+	if opts.after != nil {
+		cfg.ContextAfter = opts.after
+	}
+	return apply(cfg)
+}
+
+func apply(cfg *config.Config) error {
+	if err := ui.SetColorProfile(cfg.ColorProfile); err != nil {
+		return err
+	}
+	if err := ui.ApplyTheme(cfg.ThemeName, cfg.Theme); err != nil {
+		return err
+	}
+	if err := ui.ApplyKeymap(cfg.KeyPreset, cfg.Keys); err != nil {
+		return err
+	}
+
+	logx.SetLinePatterns(cfg.Patterns)
+	for alias, level := range cfg.LevelAliases {
+		logx.SetLevelAlias(alias, level)
+	}
+
+	app.DefaultFilter = cfg.Filter
+	if cfg.Level != logx.LevelUnknown {
+		app.DefaultLevelFilter = app.LevelFilterFor(cfg.Level)
+	}
+	if cfg.ContextBefore != nil {
+		app.DefaultContextBefore = *cfg.ContextBefore
+	}
+	if cfg.ContextAfter != nil {
+		app.DefaultContextAfter = *cfg.ContextAfter
+	}
+
+	if cfg.Limits.MaxCopyLines > 0 {
+		ui.MaxCopyLines = cfg.Limits.MaxCopyLines
+	}
+	if cfg.Limits.MaxSelectLines > 0 {
+		ui.MaxSelectLines = cfg.Limits.MaxSelectLines
+	}
+	if cfg.Limits.MaxTextFilterLines > 0 {
+		ui.MaxTextFilterLines = cfg.Limits.MaxTextFilterLines
+	}
+	if cfg.Limits.AsyncLoadingLines > 0 {
+		asyncLoadingThreshold = cfg.Limits.AsyncLoadingLines
+	}
+	if cfg.BurstWindows != nil {
+		signal.BurstWindows = cfg.BurstWindows
+	}
+	return nil
+}
+
+func runConfig(args []string) int {
+	if len(args) == 0 || args[0] != "check" {
+		fmt.Fprintln(os.Stderr, "Usage: lx config check [file...]")
+		return 2
+	}
+
+	paths := args[1:]
+	if len(paths) == 0 {
+		dir, err := os.Getwd()
+		if err != nil {
+			dir = "."
+		}
+		paths = config.Layers(dir)
+	}
+	if len(paths) == 0 {
+		fmt.Printf("No config found (looked for %s and %s)\n", config.UserPath(), config.ProjectFileName)
+		return 0
+	}
+
+	status := 0
+	for _, path := range paths {
+		cfg, err := config.Load(path)
+		if err == nil {
+			err = apply(cfg)
+			if err != nil {
+				err = fmt.Errorf("%s: %v", path, err)
+			}
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			status = 1
+			continue
+		}
+		fmt.Printf("%s: ok\n", path)
+	}
+	return status
+}
+
+func runLive(mode input.Mode, name string, lineCh <-chan string, errCh <-chan error, opts ...tea.ProgramOption) {
+	state := app.NewLoadingState(mode, name)
+	state.IsLive = true
+	state.IsLoading = false
+	model := newModel(state)
+	opts = append([]tea.ProgramOption{tea.WithAltScreen(), tea.WithMouseCellMotion()}, opts...)
+	p := tea.NewProgram(model, opts...)
+
+	go func() {
+		var batch []string
+		parser := logx.NewParser()
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case line, ok := <-lineCh:
+				if !ok {
+					if entries := append(parser.Parse(batch), parser.Flush()...); len(entries) > 0 {
+						p.Send(ui.LiveBatchMsg{Entries: entries})
+					}
+					p.Send(ui.LiveStoppedMsg{})
+					select {
+					case err := <-errCh:
+						p.Send(ui.LiveErrorMsg{Err: err})
+					default:
+					}
+					return
+				}
+				batch = append(batch, line)
+				if len(batch) >= 100 {
+					p.Send(ui.LiveBatchMsg{Entries: parser.Parse(batch)})
+					batch = nil
+				}
+			case <-ticker.C:
+				if len(batch) > 0 {
+					p.Send(ui.LiveBatchMsg{Entries: parser.Parse(batch)})
+					batch = nil
+				} else if entries := parser.Flush(); len(entries) > 0 {
+					p.Send(ui.LiveBatchMsg{Entries: entries})
+				}
+			case err := <-errCh:
+				p.Send(ui.LiveErrorMsg{Err: err})
+			}
+		}
+	}()
+
+	p.Run()
+}
+
+func runCommand(command []string) {
+	state := app.NewLoadingState(input.ModeCommand, strings.Join(command, " "))
+	state.IsLoading = false
+	model := newModel(state)
+	model.Command = command
+	p := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
+
+	final, err := p.Run()
+	if m, ok := final.(ui.Model); ok {
+		m.StopRuns()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error running program: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,85 @@
+package app
+
+const (
+	ColumnMinWidth = 3
+	ColumnMaxWidth = 80
+)
+
+type FieldCount struct {
+	Key   string
+	Count int
+}
+
+func (s *State) CommonFields() []FieldCount {
+	stats := s.FieldStats()
+	fields := make([]FieldCount, len(stats))
+	for i, stat := range stats {
+		fields[i] = FieldCount{Key: stat.Key, Count: stat.Count}
+	}
+	return fields
+}
+
+func (s *State) OpenColumnPicker() {
+	common := s.CommonFields()
+	counts := make(map[string]int, len(common))
+	for _, f := range common {
+		counts[f.Key] = f.Count
+	}
+	choices := make([]FieldCount, 0, len(common)+len(s.Columns))
+	for _, key := range s.Columns {
+		choices = append(choices, FieldCount{Key: key, Count: counts[key]})
+	}
+	for _, f := range common {
+		if !s.HasColumn(f.Key) {
+			choices = append(choices, f)
+		}
+	}
+	s.ColumnChoices = choices
+	s.ColumnCursor = 0
+	s.Mode = ModeColumns
+}
+
+func (s *State) SelectedColumnChoice() string {
+	if s.ColumnCursor < 0 || s.ColumnCursor >= len(s.ColumnChoices) {
+		return ""
+	}
+	return s.ColumnChoices[s.ColumnCursor].Key
+}
+
+func (s *State) HasColumn(key string) bool {
+	for _, col := range s.Columns {
+		if col == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *State) ToggleColumn(key string) {
+	for i, col := range s.Columns {
+		if col == key {
+			s.Columns = append(s.Columns[:i:i], s.Columns[i+1:]...)
+			delete(s.ColumnWidths, key)
+			return
+		}
+	}
+	s.Columns = append(s.Columns, key)
+}
+
+func (s *State) ResizeColumn(key string, width int) {
+	if width < ColumnMinWidth {
+		width = ColumnMinWidth
+	}
+	if width > ColumnMaxWidth {
+		width = ColumnMaxWidth
+	}
+	if s.ColumnWidths == nil {
+		s.ColumnWidths = make(map[string]int)
+	}
+	s.ColumnWidths[key] = width
+}
+
+func (s *State) ClearColumns() {
+	s.Columns = nil
+	s.ColumnWidths = nil
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToggleColumn(t *testing.T) {
+	s := newTestState()
+	for _, key := range []string{"service", "user", "http.status"} {
+		s.ToggleColumn(key)
+	}
+	s.ResizeColumn("user", 20)
+	before := s.Columns
+
+	s.ToggleColumn("user")
+	if !reflect.DeepEqual(s.Columns, []string{"service", "http.status"}) {
+		t.Errorf("Columns = %v", s.Columns)
+	}
+	if _, ok := s.ColumnWidths["user"]; ok {
+		t.Error("width kept for a removed column")
+	}
+	if !reflect.DeepEqual(before, []string{"service", "user", "http.status"}) {
+		t.Errorf("removing a column changed the previous slice: %v", before)
+	}
+	if s.HasColumn("user") || !s.HasColumn("http.status") {
+		t.Errorf("HasColumn wrong for %v", s.Columns)
+	}
+
+	s.ToggleColumn("user")
+	if !reflect.DeepEqual(s.Columns, []string{"service", "http.status", "user"}) {
+		t.Errorf("re-added column not appended: %v", s.Columns)
+	}
+
+	s.ClearColumns()
+	if s.Columns != nil || s.ColumnWidths != nil {
+		t.Errorf("ClearColumns left %v %v", s.Columns, s.ColumnWidths)
+	}
+}
+
+func TestResizeColumn(t *testing.T) {
+	tests := []struct {
+		width, want int
+	}{
+		{20, 20},
+		{ColumnMinWidth - 1, ColumnMinWidth},
+		{-5, ColumnMinWidth},
+		{ColumnMaxWidth + 1, ColumnMaxWidth},
+	}
+
+	for _, tt := range tests {
+		s := newTestState()
+		s.ResizeColumn("service", tt.width)
+		if got := s.ColumnWidths["service"]; got != tt.want {
+			t.Errorf("ResizeColumn(%d) = %d, want %d", tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestOpenColumnPicker(t *testing.T) {
+	s := newTestState(
+		`{"msg":"a","service":"api","user":"bob","region":"eu"}`,
+		`{"msg":"b","service":"api","user":"amy"}`,
+		`{"msg":"c","service":"web"}`,
+	)
+	s.Columns = []string{"region", "trace_id"}
+	s.ColumnCursor = 3
+
+	s.OpenColumnPicker()
+	want := []FieldCount{{"region", 1}, {"trace_id", 0}, {"msg", 3}, {"service", 3}, {"user", 2}}
+	if !reflect.DeepEqual(s.ColumnChoices, want) {
+		t.Errorf("ColumnChoices = %v, want %v", s.ColumnChoices, want)
+	}
+	if s.Mode != ModeColumns || s.ColumnCursor != 0 || s.SelectedColumnChoice() != "region" {
+		t.Errorf("mode %v cursor %d choice %q", s.Mode, s.ColumnCursor, s.SelectedColumnChoice())
+	}
+
+	s.ColumnCursor = len(want)
+	if got := s.SelectedColumnChoice(); got != "" {
+		t.Errorf("choice past the end = %q", got)
+	}
+}
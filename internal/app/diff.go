@@ -0,0 +1,88 @@
+package app
+
+import (
+	"github.com/kalayciburak/lx/internal/diff"
+	"github.com/kalayciburak/lx/internal/logx"
+)
+
+func diffKeys(entries []logx.Entry) []string {
+	cache := make(map[string]string)
+	keys := make([]string, len(entries))
+	for i, e := range entries {
+		tmpl, ok := cache[e.Message]
+		if !ok {
+			tmpl = logx.Template(e.Message)
+			cache[e.Message] = tmpl
+		}
+		keys[i] = e.Level.String() + " " + tmpl
+	}
+	return keys
+}
+
+func (s *State) OpenDiff(other *State) {
+	left, right := other.MatchedEntries(), s.MatchedEntries()
+	rows := diff.Align(diff.Lines(diffKeys(left), diffKeys(right)))
+	for i, row := range rows {
+		if row.Kind == diff.Same && left[row.A].Message != right[row.B].Message {
+			rows[i].Kind = diff.Changed
+		}
+	}
+	s.DiffOther = other
+	s.DiffLeft = left
+	s.DiffRight = right
+	s.DiffRows = rows
+	s.DiffHunks = diff.Hunks(s.DiffRows)
+	s.DiffScroll = 0
+	if len(s.DiffHunks) > 0 {
+		s.DiffScroll = s.DiffHunks[0].Start
+	}
+	s.Mode = ModeDiff
+}
+
+func (s *State) CloseDiff() {
+	s.DiffLeft = nil
+	s.DiffRight = nil
+	s.DiffRows = nil
+	s.DiffHunks = nil
+}
+
+func (s *State) ScrollDiff(delta int) {
+	s.DiffScroll += delta
+	if s.DiffScroll > len(s.DiffRows)-1 {
+		s.DiffScroll = len(s.DiffRows) - 1
+	}
+	if s.DiffScroll < 0 {
+		s.DiffScroll = 0
+	}
+}
+
+func (s *State) DiffHunk() int {
+	for i, h := range s.DiffHunks {
+		if s.DiffScroll >= h.Start && s.DiffScroll < h.End {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *State) JumpHunk(delta int) bool {
+	i := len(s.DiffHunks)
+	for j, h := range s.DiffHunks {
+		if s.DiffScroll < h.End {
+			i = j
+			break
+		}
+	}
+	if delta > 0 {
+		if i < len(s.DiffHunks) && s.DiffScroll >= s.DiffHunks[i].Start {
+			i++
+		}
+	} else {
+		i--
+	}
+	if i < 0 || i >= len(s.DiffHunks) {
+		return false
+	}
+	s.DiffScroll = s.DiffHunks[i].Start
+	return true
+}
@@ -0,0 +1,126 @@
+package app
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kalayciburak/lx/internal/diff"
+)
+
+func TestOpenDiff(t *testing.T) {
+	base := newTestState(
+		"INFO starting server",
+		"INFO listening on :8080",
+		"INFO request served in 12ms",
+		"ERROR db timeout",
+		"INFO shutdown",
+	)
+	cur := newTestState(
+		"INFO starting server",
+		"INFO listening on :9090",
+		"INFO request served in 12ms",
+		"WARN db slow",
+		"INFO cache warm",
+		"INFO shutdown",
+	)
+
+	cur.OpenDiff(base)
+	want := []diff.Row{
+		{Kind: diff.Same, A: 0, B: 0},
+		{Kind: diff.Changed, A: 1, B: 1},
+		{Kind: diff.Same, A: 2, B: 2},
+		{Kind: diff.Changed, A: 3, B: 3},
+		{Kind: diff.Added, A: -1, B: 4},
+		{Kind: diff.Same, A: 4, B: 5},
+	}
+	if !reflect.DeepEqual(cur.DiffRows, want) {
+		t.Errorf("DiffRows = %v, want %v", cur.DiffRows, want)
+	}
+	if !reflect.DeepEqual(cur.DiffHunks, []diff.Hunk{{Start: 1, End: 2}, {Start: 3, End: 5}}) {
+		t.Errorf("DiffHunks = %v", cur.DiffHunks)
+	}
+	if cur.Mode != ModeDiff || cur.DiffOther != base || cur.DiffScroll != 1 {
+		t.Errorf("mode %v other %p scroll %d", cur.Mode, cur.DiffOther, cur.DiffScroll)
+	}
+
+	cur.CloseDiff()
+	if cur.DiffRows != nil || cur.DiffHunks != nil || cur.DiffLeft != nil || cur.DiffRight != nil {
+		t.Error("CloseDiff kept diff rows")
+	}
+}
+
+func TestOpenDiffMatchedOnly(t *testing.T) {
+	base := newTestState("INFO a", "ERROR b", "INFO c")
+	cur := newTestState("INFO a", "INFO x", "ERROR b", "INFO c")
+	cur.FilterQuery = "b -C1"
+	cur.Refilter()
+
+	cur.OpenDiff(base)
+	if len(cur.DiffRight) != 1 || cur.DiffRight[0].Index != 2 {
+		t.Fatalf("DiffRight = %v, want only the match", cur.DiffRight)
+	}
+	if len(cur.DiffHunks) != 2 {
+		t.Errorf("DiffHunks = %v", cur.DiffHunks)
+	}
+}
+
+func diffState(kinds string) *State {
+	s := newTestState()
+	for i, k := range kinds {
+		row := diff.Row{Kind: diff.Same, A: i, B: i}
+		if k == '+' {
+			row.Kind = diff.Added
+		}
+		s.DiffRows = append(s.DiffRows, row)
+	}
+	s.DiffHunks = diff.Hunks(s.DiffRows)
+	return s
+}
+
+func TestJumpHunk(t *testing.T) {
+	tests := []struct {
+		name   string
+		scroll int
+		delta  int
+		want   int
+		moved  bool
+	}{
+		{"next from the top", 0, 1, 2, true},
+		{"next from inside a hunk", 3, 1, 6, true},
+		{"next from the last hunk", 6, 1, 6, false},
+		{"next past the last hunk", 8, 1, 8, false},
+		{"prev from inside a hunk", 7, -1, 2, true},
+		{"prev from between hunks", 5, -1, 2, true},
+		{"prev from the first hunk", 2, -1, 2, false},
+		{"prev from the top", 0, -1, 0, false},
+	}
+
+	for _, tt := range tests {
+		s := diffState("  ++  ++ ")
+		s.DiffScroll = tt.scroll
+		moved := s.JumpHunk(tt.delta)
+		if moved != tt.moved || s.DiffScroll != tt.want {
+			t.Errorf("%s: JumpHunk(%d) = %v at %d, want %v at %d", tt.name, tt.delta, moved, s.DiffScroll, tt.moved, tt.want)
+		}
+	}
+}
+
+func TestScrollDiff(t *testing.T) {
+	s := diffState("  ++  ++ ")
+	tests := []struct {
+		delta, want, hunk int
+	}{
+		{3, 3, 0},
+		{2, 5, -1},
+		{100, 8, -1},
+		{-1, 7, 1},
+		{-100, 0, -1},
+	}
+
+	for _, tt := range tests {
+		s.ScrollDiff(tt.delta)
+		if s.DiffScroll != tt.want || s.DiffHunk() != tt.hunk {
+			t.Errorf("ScrollDiff(%d) = %d in hunk %d, want %d in hunk %d", tt.delta, s.DiffScroll, s.DiffHunk(), tt.want, tt.hunk)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package app
+
+import (
+	"sort"
+
+	"github.com/kalayciburak/lx/internal/logx"
+	"github.com/kalayciburak/lx/internal/signal"
+)
+
+const FieldTopValues = 10
+
+type ValueCount struct {
+	Value string
+	Count int
+}
+
+type FieldStat struct {
+	Key      string
+	Count    int
+	Distinct int
+	Values   []ValueCount
+}
+
+func (s *State) FieldStats() []FieldStat {
+	values := make(map[string]map[string]int)
+	for _, idx := range s.Filtered {
+		collectFields(s.Entries[idx].Fields, "", values)
+	}
+
+	stats := make([]FieldStat, 0, len(values))
+	for key, counts := range values {
+		stat := FieldStat{Key: key, Distinct: len(counts)}
+		stat.Values = make([]ValueCount, 0, len(counts))
+		for value, n := range counts {
+			stat.Count += n
+			stat.Values = append(stat.Values, ValueCount{Value: value, Count: n})
+		}
+		sort.Slice(stat.Values, func(i, j int) bool {
+			if stat.Values[i].Count != stat.Values[j].Count {
+				return stat.Values[i].Count > stat.Values[j].Count
+			}
+			return stat.Values[i].Value < stat.Values[j].Value
+		})
+		if len(stat.Values) > FieldTopValues {
+			stat.Values = stat.Values[:FieldTopValues]
+		}
+		stats = append(stats, stat)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Count != stats[j].Count {
+			return stats[i].Count > stats[j].Count
+		}
+		return stats[i].Key < stats[j].Key
+	})
+	return stats
+}
+
+func collectFields(fields map[string]any, prefix string, values map[string]map[string]int) {
+	for key, val := range fields {
+		if nested, ok := val.(map[string]any); ok && len(nested) > 0 {
+			collectFields(nested, prefix+key+".", values)
+			continue
+		}
+		counts, ok := values[prefix+key]
+		if !ok {
+			counts = make(map[string]int)
+			values[prefix+key] = counts
+		}
+		counts[logx.FieldValue(val)]++
+	}
+}
+
+func (s *State) OpenFieldExplorer() {
+	s.FieldExplorer = s.FieldStats()
+	s.FieldCursor = 0
+	s.FieldValueCursor = 0
+	s.FieldFocusValues = false
+	s.Mode = ModeFields
+}
+
+func (s *State) SelectedFieldStat() *FieldStat {
+	if s.FieldCursor < 0 || s.FieldCursor >= len(s.FieldExplorer) {
+		return nil
+	}
+	return &s.FieldExplorer[s.FieldCursor]
+}
+
+func (s *State) AddFilterTerm(term string) {
+	if s.FilterQuery != "" {
+		term = s.FilterQuery + " " + term
+	}
+	s.FilterQuery = term
+	s.ClearExpandedContext()
+	s.Refilter()
+}
+
+func (s *State) FilterErrorMessage(msg string) string {
+	if r := s.LevelRange(); r == nil || r.Min < logx.LevelError || s.LevelMode != LevelModeAtLeast {
+		s.LevelFilter = LevelFilterError
+		s.LevelFilterMax = LevelFilterAll
+		s.LevelMode = LevelModeAtLeast
+	}
+	term := logx.FieldTerm(logx.MessageField, msg, false)
+	s.AddFilterTerm(term)
+	return term
+}
+
+const statsMaxGroups = 50
+
+func (s *State) StatsCandidates() (numeric, groups []string) {
+	for _, stat := range s.FieldStats() {
+		if isNumericStat(stat) {
+			numeric = append(numeric, stat.Key)
+		} else if stat.Distinct > 1 && stat.Distinct <= statsMaxGroups {
+			groups = append(groups, stat.Key)
+		}
+	}
+	return numeric, groups
+}
+
+func isNumericStat(stat FieldStat) bool {
+	for _, v := range stat.Values {
+		if !signal.IsNumeric(v.Value) {
+			return false
+		}
+	}
+	return len(stat.Values) > 0
+}
+
+func (s *State) StatsField() string {
+	if s.StatsFieldIdx < 0 || s.StatsFieldIdx >= len(s.StatsFields) {
+		return ""
+	}
+	return s.StatsFields[s.StatsFieldIdx]
+}
+
+func (s *State) StatsGroupBy() string {
+	if s.StatsGroupIdx <= 0 || s.StatsGroupIdx > len(s.StatsGroupFields) {
+		return ""
+	}
+	return s.StatsGroupFields[s.StatsGroupIdx-1]
+}
@@ -0,0 +1,161 @@
+package app
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/kalayciburak/lx/internal/input"
+	"github.com/kalayciburak/lx/internal/logx"
+)
+
+func newTestState(lines ...string) *State {
+	return NewState(logx.ParseLines(lines), input.ModeFile, "test.log")
+}
+
+func fieldStat(stats []FieldStat, key string) *FieldStat {
+	for i := range stats {
+		if stats[i].Key == key {
+			return &stats[i]
+		}
+	}
+	return nil
+}
+
+func TestFieldStats(t *testing.T) {
+	s := newTestState(
+		`{"level":"error","msg":"charge failed","service":"payments","http":{"status":500},"ms":120}`,
+		`{"level":"info","msg":"charge ok","service":"payments","http":{"status":200},"ms":80}`,
+		`{"level":"info","msg":"login","service":"auth","http":{"status":200}}`,
+		`plain text line`,
+	)
+
+	stats := s.FieldStats()
+	tests := []struct {
+		key      string
+		count    int
+		distinct int
+		values   []ValueCount
+	}{
+		{"service", 3, 2, []ValueCount{{"payments", 2}, {"auth", 1}}},
+		{"http.status", 3, 2, []ValueCount{{"200", 2}, {"500", 1}}},
+		{"ms", 2, 2, []ValueCount{{"120", 1}, {"80", 1}}},
+	}
+	for _, tt := range tests {
+		stat := fieldStat(stats, tt.key)
+		if stat == nil {
+			t.Errorf("no stats for %s in %+v", tt.key, stats)
+			continue
+		}
+		if stat.Count != tt.count || stat.Distinct != tt.distinct || !reflect.DeepEqual(stat.Values, tt.values) {
+			t.Errorf("%s = %d/%d %v, want %d/%d %v", tt.key, stat.Count, stat.Distinct, stat.Values, tt.count, tt.distinct, tt.values)
+		}
+	}
+	if fieldStat(stats, "http") != nil {
+		t.Error("nested object counted as a field")
+	}
+	for i := 1; i < len(stats); i++ {
+		if stats[i-1].Count < stats[i].Count || stats[i-1].Count == stats[i].Count && stats[i-1].Key > stats[i].Key {
+			t.Errorf("stats not sorted by count, then key: %s before %s", stats[i-1].Key, stats[i].Key)
+		}
+	}
+
+	s.FilterQuery = "service=auth"
+	s.Refilter()
+	if stat := fieldStat(s.FieldStats(), "service"); stat == nil || stat.Count != 1 {
+		t.Errorf("filtered service stats = %+v, want only the auth line", stat)
+	}
+}
+
+func TestFieldStatsTopValues(t *testing.T) {
+	var lines []string
+	for i := 0; i < FieldTopValues+5; i++ {
+		lines = append(lines, `{"msg":"hit","user":"u`+strconv.Itoa(i)+`"}`)
+	}
+	lines = append(lines, `{"msg":"hit","user":"u7"}`)
+
+	stat := fieldStat(newTestState(lines...).FieldStats(), "user")
+	if stat == nil || stat.Distinct != FieldTopValues+5 || len(stat.Values) != FieldTopValues {
+		t.Fatalf("user stats = %+v", stat)
+	}
+	if stat.Values[0] != (ValueCount{"u7", 2}) || stat.Values[1] != (ValueCount{"u0", 1}) {
+		t.Errorf("top values = %v", stat.Values[:2])
+	}
+}
+
+func TestAddFilterTerm(t *testing.T) {
+	s := newTestState(
+		`{"msg":"charge failed","service":"payments"}`,
+		`{"msg":"login","service":"auth"}`,
+	)
+	s.ExpandedContext = map[int]int{0: 5}
+
+	s.AddFilterTerm("service=payments")
+	if s.FilterQuery != "service=payments" || !reflect.DeepEqual(s.Filtered, []int{0}) {
+		t.Errorf("query %q filtered %v", s.FilterQuery, s.Filtered)
+	}
+	if s.ExpandedContext != nil {
+		t.Error("expanded context kept after adding a term")
+	}
+
+	s.AddFilterTerm("!service=payments")
+	if s.FilterQuery != "service=payments !service=payments" || len(s.Filtered) != 0 {
+		t.Errorf("query %q filtered %v", s.FilterQuery, s.Filtered)
+	}
+}
+
+func TestFilterErrorMessage(t *testing.T) {
+	lines := []string{
+		`{"level":"error","msg":"disk full"}`,
+		`{"level":"info","msg":"disk full"}`,
+		`{"level":"error","msg":"disk full on /var"}`,
+		`{"level":"fatal","msg":"disk full"}`,
+	}
+	tests := []struct {
+		name      string
+		level     LevelFilter
+		max       LevelFilter
+		mode      LevelMode
+		wantLevel LevelFilter
+		want      []int
+	}{
+		{"no level filter", LevelFilterAll, LevelFilterAll, LevelModeAtLeast, LevelFilterError, []int{0, 3}},
+		{"lower level filter", LevelFilterWarn, LevelFilterAll, LevelModeAtLeast, LevelFilterError, []int{0, 3}},
+		{"stricter level filter", LevelFilterFatal, LevelFilterAll, LevelModeAtLeast, LevelFilterFatal, []int{3}},
+		{"exact error", LevelFilterError, LevelFilterAll, LevelModeExact, LevelFilterError, []int{0, 3}},
+		{"exact fatal", LevelFilterFatal, LevelFilterAll, LevelModeExact, LevelFilterError, []int{0, 3}},
+		{"range ending below fatal", LevelFilterWarn, LevelFilterError, LevelModeRange, LevelFilterError, []int{0, 3}},
+		{"range from error", LevelFilterError, LevelFilterCritical, LevelModeRange, LevelFilterError, []int{0, 3}},
+	}
+
+	for _, tt := range tests {
+		s := newTestState(lines...)
+		s.LevelFilter, s.LevelFilterMax, s.LevelMode = tt.level, tt.max, tt.mode
+		term := s.FilterErrorMessage("disk full")
+		if term != `@msg="disk full"` || s.FilterQuery != term {
+			t.Errorf("%s: term %q query %q", tt.name, term, s.FilterQuery)
+		}
+		if s.LevelFilter != tt.wantLevel || !reflect.DeepEqual(s.Filtered, tt.want) {
+			t.Errorf("%s: level %v filtered %v, want %v %v", tt.name, s.LevelFilter, s.Filtered, tt.wantLevel, tt.want)
+		}
+		if r := s.LevelRange(); r.Max != logx.LevelFatal {
+			t.Errorf("%s: range %v..%v does not reach FATAL", tt.name, r.Min, r.Max)
+		}
+	}
+}
+
+func TestStatsCandidates(t *testing.T) {
+	s := newTestState(
+		`{"msg":"req","ms":12,"route":"/a","host":"h1","id":"x1"}`,
+		`{"msg":"req","ms":"15.5","route":"/b","host":"h1","id":"x2"}`,
+		`{"msg":"req","ms":9,"route":"/a","host":"h1","id":"x3"}`,
+	)
+
+	numeric, groups := s.StatsCandidates()
+	if !reflect.DeepEqual(numeric, []string{"ms"}) {
+		t.Errorf("numeric = %v, want [ms]", numeric)
+	}
+	if !reflect.DeepEqual(groups, []string{"id", "route"}) {
+		t.Errorf("groups = %v, want [id route]", groups)
+	}
+}
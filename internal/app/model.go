@@ -3,14 +3,26 @@ package app
 import (
 	"time"
 
+	"github.com/charmbracelet/x/ansi"
+	"github.com/kalayciburak/lx/internal/diff"
 	"github.com/kalayciburak/lx/internal/input"
 	"github.com/kalayciburak/lx/internal/logx"
 	"github.com/kalayciburak/lx/internal/lookup"
+	"github.com/kalayciburak/lx/internal/runner"
 	"github.com/kalayciburak/lx/internal/signal"
 )
 
 type Mode int
 
+const ContextExpandStep = 5
+
+var (
+	DefaultFilter        string
+	DefaultLevelFilter   = LevelFilterAll
+	DefaultContextBefore int
+	DefaultContextAfter  int
+)
+
 const (
 	ModeList Mode = iota
 	ModeFilter
@@ -21,20 +33,50 @@ const (
 	ModeSignal
 	ModeOpenFile
 	ModeQuitConfirm
+	ModeCorrelation
+	ModeColumns
+	ModeFields
+	ModeCompare
+	ModeDiff
 )
 
 type LevelFilter int
 
 const (
 	LevelFilterAll LevelFilter = iota
+	LevelFilterFatal
+	LevelFilterAlert
+	LevelFilterCritical
 	LevelFilterError
 	LevelFilterWarn
+	LevelFilterNotice
 	LevelFilterInfo
 	LevelFilterDebug
 	LevelFilterTrace
 )
 
-var LevelFilters = []LevelFilter{LevelFilterAll, LevelFilterError, LevelFilterWarn, LevelFilterInfo, LevelFilterDebug, LevelFilterTrace}
+var LevelFilters = []LevelFilter{LevelFilterAll, LevelFilterFatal, LevelFilterAlert, LevelFilterCritical, LevelFilterError, LevelFilterWarn, LevelFilterNotice, LevelFilterInfo, LevelFilterDebug, LevelFilterTrace}
+
+type LevelMode int
+
+const (
+	LevelModeAtLeast LevelMode = iota
+	LevelModeExact
+	LevelModeRange
+)
+
+var LevelModes = []LevelMode{LevelModeAtLeast, LevelModeExact, LevelModeRange}
+
+func (lm LevelMode) String() string {
+	switch lm {
+	case LevelModeExact:
+		return "exact"
+	case LevelModeRange:
+		return "range"
+	default:
+		return "and above"
+	}
+}
 
 type NoteLevel int
 
@@ -87,19 +129,43 @@ func ParseNoteLevel(text string) (NoteLevel, string) {
 }
 
 func (lf LevelFilter) String() string {
+	if lf == LevelFilterAll {
+		return "ALL"
+	}
+	return lf.Level().String()
+}
+
+func LevelFilterFor(level logx.Level) LevelFilter {
+	for _, lf := range LevelFilters {
+		if lf != LevelFilterAll && lf.Level() == level {
+			return lf
+		}
+	}
+	return LevelFilterAll
+}
+
+func (lf LevelFilter) Level() logx.Level {
 	switch lf {
+	case LevelFilterFatal:
+		return logx.LevelFatal
+	case LevelFilterAlert:
+		return logx.LevelAlert
+	case LevelFilterCritical:
+		return logx.LevelCritical
 	case LevelFilterError:
-		return "ERROR"
+		return logx.LevelError
 	case LevelFilterWarn:
-		return "WARN"
+		return logx.LevelWarn
+	case LevelFilterNotice:
+		return logx.LevelNotice
 	case LevelFilterInfo:
-		return "INFO"
+		return logx.LevelInfo
 	case LevelFilterDebug:
-		return "DEBUG"
+		return logx.LevelDebug
 	case LevelFilterTrace:
-		return "TRACE"
+		return logx.LevelTrace
 	default:
-		return "ALL"
+		return logx.LevelUnknown
 	}
 }
 
@@ -110,8 +176,17 @@ type State struct {
 	Cursor   int
 	Selected map[int]bool
 
-	FilterQuery string
-	LevelFilter LevelFilter
+	FilterQuery         string
+	LevelFilter         LevelFilter
+	LevelFilterMax      LevelFilter
+	LevelMode           LevelMode
+	IncludeContinuation bool
+
+	ContextBefore   int
+	ContextAfter    int
+	ContextRows     map[int]bool
+	ExpandedContext map[int]int
+	ShowHunks       bool
 
 	Mode Mode
 
@@ -130,8 +205,48 @@ type State struct {
 
 	DetailScroll    int
 	DetailMaximized bool
+	ShowANSI        bool
+	HScroll         int
+	WidestMessage   int
+	WidestScanned   int
+	Wrap            bool
 	SignalResult    *signal.SignalResult
 
+	Columns       []string
+	ColumnWidths  map[string]int
+	ColumnChoices []FieldCount
+	ColumnCursor  int
+
+	FieldExplorer    []FieldStat
+	FieldCursor      int
+	FieldValueCursor int
+	FieldFocusValues bool
+
+	StatsFields      []string
+	StatsFieldIdx    int
+	StatsGroupFields []string
+	StatsGroupIdx    int
+	RateAllVisible   bool
+	TemplateCache    signal.TemplateCache
+	SignalCursor     int
+
+	Baseline      bool
+	Compare       *signal.CompareResult
+	CompareBase   *State
+	CompareCursor int
+
+	DiffOther  *State
+	DiffLeft   []logx.Entry
+	DiffRight  []logx.Entry
+	DiffRows   []diff.Row
+	DiffHunks  []diff.Hunk
+	DiffScroll int
+
+	Correlation       *signal.CorrelationResult
+	CorrelationIDs    []logx.CorrelationID
+	CorrelationIDIdx  int
+	CorrelationCursor int
+
 	OpenFilePath        string
 	OpenFileCursor      int
 	OpenFileSuggestions []string
@@ -146,6 +261,8 @@ type State struct {
 
 	IsLoading       bool
 	IsLive          bool
+	Run             *runner.Process
+	RunNumber       int
 	LoadingProgress int
 }
 
@@ -157,44 +274,69 @@ func NewState(entries []logx.Entry, inputMode input.Mode, fileName string) *Stat
 		}
 	}
 
-	return &State{
-		Entries:      entries,
-		Filtered:     filtered,
-		InputMode:    inputMode,
-		FileName:     fileName,
-		Mode:         ModeList,
-		Notes:        make(map[int]Note),
-		NoteLineIdx:  -1,
-		ShowingNotes: make(map[int]bool),
-		Selected:     make(map[int]bool),
+	s := &State{
+		Entries:       entries,
+		Filtered:      filtered,
+		InputMode:     inputMode,
+		FileName:      fileName,
+		Mode:          ModeList,
+		Notes:         make(map[int]Note),
+		NoteLineIdx:   -1,
+		ShowingNotes:  make(map[int]bool),
+		Selected:      make(map[int]bool),
+		ContextRows:   make(map[int]bool),
+		FilterQuery:   DefaultFilter,
+		LevelFilter:   DefaultLevelFilter,
+		ContextBefore: DefaultContextBefore,
+		ContextAfter:  DefaultContextAfter,
+	}
+	if s.IsFiltering() {
+		s.Refilter()
 	}
+	return s
 }
 
 func NewLoadingState(inputMode input.Mode, fileName string) *State {
 	return &State{
-		Entries:      make([]logx.Entry, 0),
-		Filtered:     make([]int, 0),
-		InputMode:    inputMode,
-		FileName:     fileName,
-		Mode:         ModeList,
-		Notes:        make(map[int]Note),
-		NoteLineIdx:  -1,
-		ShowingNotes: make(map[int]bool),
-		Selected:     make(map[int]bool),
-		IsLoading:    true,
+		Entries:       make([]logx.Entry, 0),
+		Filtered:      make([]int, 0),
+		InputMode:     inputMode,
+		FileName:      fileName,
+		Mode:          ModeList,
+		Notes:         make(map[int]Note),
+		NoteLineIdx:   -1,
+		ShowingNotes:  make(map[int]bool),
+		Selected:      make(map[int]bool),
+		ContextRows:   make(map[int]bool),
+		FilterQuery:   DefaultFilter,
+		LevelFilter:   DefaultLevelFilter,
+		ContextBefore: DefaultContextBefore,
+		ContextAfter:  DefaultContextAfter,
+		IsLoading:     true,
 	}
 }
 
 func (s *State) AppendEntries(newEntries []logx.Entry) {
 	startIdx := len(s.Entries)
 	s.Entries = append(s.Entries, newEntries...)
+	s.LoadingProgress = len(s.Entries)
 
-	for i := range newEntries {
-		if !newEntries[i].Deleted {
-			s.Filtered = append(s.Filtered, startIdx+i)
+	if !s.IsFiltering() {
+		for i := range newEntries {
+			if !newEntries[i].Deleted {
+				s.Filtered = append(s.Filtered, startIdx+i)
+			}
 		}
+		return
 	}
-	s.LoadingProgress = len(s.Entries)
+
+	if s.ShowHunks {
+		scroll := s.DetailScroll
+		s.Refilter()
+		s.DetailScroll = scroll
+		return
+	}
+	s.Filtered = append(s.Filtered, logx.ApplyFrom(s.Entries, startIdx, s.FilterQuery, s.LevelRange())...)
 }
 
 func (s *State) FinishLoading() {
@@ -203,12 +345,20 @@ func (s *State) FinishLoading() {
 }
 
 func (s *State) Refilter() {
-	var levelPtr *logx.Level
-	if s.LevelFilter != LevelFilterAll {
-		level := s.levelFilterToLogxLevel()
-		levelPtr = &level
+	matches := logx.ApplyWithLevel(s.Entries, s.FilterQuery, s.LevelRange())
+	before, after := 0, 0
+	expanded := s.ExpandedContext
+	if s.IsFiltering() {
+		before, after = logx.NewFilter(s.FilterQuery).Context()
+		if before == 0 && after == 0 {
+			before, after = s.ContextBefore, s.ContextAfter
+		}
+	} else {
+		expanded = nil
 	}
-	s.Filtered = logx.ApplyWithLevel(s.Entries, s.FilterQuery, levelPtr)
+	s.Filtered, s.ContextRows = logx.WithContext(s.Entries, matches, before, after, expanded)
+	s.WidestMessage, s.WidestScanned = 0, 0
+	s.ShowHunks = before > 0 || after > 0 || len(expanded) > 0
 	if s.Cursor >= len(s.Filtered) {
 		s.Cursor = len(s.Filtered) - 1
 	}
@@ -218,21 +368,129 @@ func (s *State) Refilter() {
 	s.DetailScroll = 0
 }
 
-func (s *State) levelFilterToLogxLevel() logx.Level {
-	switch s.LevelFilter {
-	case LevelFilterError:
-		return logx.LevelError
-	case LevelFilterWarn:
-		return logx.LevelWarn
-	case LevelFilterInfo:
-		return logx.LevelInfo
-	case LevelFilterDebug:
-		return logx.LevelDebug
-	case LevelFilterTrace:
-		return logx.LevelTrace
+func (s *State) IsFiltering() bool {
+	return s.LevelFilter != LevelFilterAll || !logx.NewFilter(s.FilterQuery).IsEmpty()
+}
+
+func (s *State) IsContextRow(idx int) bool {
+	return s.ContextRows[idx]
+}
+
+func (s *State) MatchCount() int {
+	return len(s.Filtered) - len(s.ContextRows)
+}
+
+func (s *State) ExpandContextAt(idx int) bool {
+	if idx < 0 || !s.IsFiltering() {
+		return false
+	}
+	if s.ExpandedContext == nil {
+		s.ExpandedContext = make(map[int]int)
+	}
+	s.ExpandedContext[s.nearestMatch(idx)] += ContextExpandStep
+	s.Refilter()
+	s.JumpToEntry(idx)
+	return true
+}
+
+func (s *State) nearestMatch(idx int) int {
+	best, bestDist := idx, -1
+	for _, f := range s.Filtered {
+		if s.ContextRows[f] {
+			continue
+		}
+		dist := f - idx
+		if dist < 0 {
+			dist = -dist
+		}
+		if bestDist < 0 || dist < bestDist {
+			best, bestDist = f, dist
+		}
+	}
+	return best
+}
+
+func (s *State) ClearExpandedContext() {
+	s.ExpandedContext = nil
+}
+
+func (s *State) LevelRange() *logx.LevelRange {
+	if s.LevelFilter == LevelFilterAll {
+		return nil
+	}
+	r := &logx.LevelRange{
+		Min:                 s.LevelFilter.Level(),
+		Max:                 logx.LevelFatal,
+		IncludeContinuation: s.IncludeContinuation,
+	}
+	switch s.LevelMode {
+	case LevelModeExact:
+		r.Max = r.Min
+	case LevelModeRange:
+		if s.LevelFilterMax != LevelFilterAll {
+			r.Max = s.LevelFilterMax.Level()
+		}
+		if r.Max < r.Min {
+			r.Min, r.Max = r.Max, r.Min
+		}
+	}
+	return r
+}
+
+func (s *State) LevelFilterLabel() string {
+	if s.LevelFilter == LevelFilterAll {
+		return "ALL"
+	}
+	var label string
+	switch s.LevelMode {
+	case LevelModeExact:
+		label = "=" + s.LevelFilter.String()
+	case LevelModeRange:
+		r := s.LevelRange()
+		label = r.Min.String() + ".." + r.Max.String()
 	default:
-		return logx.LevelUnknown
+		label = "≥" + s.LevelFilter.String()
+	}
+	if s.IncludeContinuation {
+		label += "+"
 	}
+	return label
+}
+
+func (s *State) CycleLevelMode() {
+	s.LevelMode = LevelModes[(int(s.LevelMode)+1)%len(LevelModes)]
+	if s.LevelMode == LevelModeRange && s.LevelFilterMax == LevelFilterAll {
+		s.LevelFilterMax = s.LevelFilter
+	}
+	s.Refilter()
+}
+
+func (s *State) CycleLevelFilterMax() {
+	s.LevelMode = LevelModeRange
+	currentIdx := 0
+	for i, lf := range LevelFilters {
+		if lf == s.LevelFilterMax {
+			currentIdx = i
+			break
+		}
+	}
+	nextIdx := currentIdx%(len(LevelFilters)-1) + 1
+	s.LevelFilterMax = LevelFilters[nextIdx]
+	if s.LevelFilter == LevelFilterAll {
+		s.LevelFilter = s.LevelFilterMax
+	}
+	s.Refilter()
+}
+
+func (s *State) ToggleContinuation() {
+	s.IncludeContinuation = !s.IncludeContinuation
+	s.Refilter()
+}
+
+func (s *State) ClearLevelFilter() {
+	s.LevelFilter = LevelFilterAll
+	s.LevelFilterMax = LevelFilterAll
+	s.LevelMode = LevelModeAtLeast
 }
 
 func (s *State) CycleLevelFilter() {
@@ -276,6 +534,34 @@ func (s *State) MoveCursor(delta int) {
 	s.DetailScroll = 0
 }
 
+func (s *State) ScrollColumns(delta, visible int) {
+	s.HScroll += delta
+	if max := s.MessageWidth() - visible; s.HScroll > max {
+		s.HScroll = max
+	}
+	if s.HScroll < 0 {
+		s.HScroll = 0
+	}
+}
+
+func (s *State) MessageWidth() int {
+	if s.WidestScanned > len(s.Filtered) {
+		s.WidestMessage, s.WidestScanned = 0, 0
+	}
+	for _, idx := range s.Filtered[s.WidestScanned:] {
+		if w := ansi.StringWidth(s.Entries[idx].Message); w > s.WidestMessage {
+			s.WidestMessage = w
+		}
+	}
+	s.WidestScanned = len(s.Filtered)
+	return s.WidestMessage
+}
+
+func (s *State) ToggleWrap() {
+	s.Wrap = !s.Wrap
+	s.HScroll = 0
+}
+
 func (s *State) ClearAll() {
 	for i := range s.Entries {
 		s.Entries[i].Deleted = true
@@ -287,6 +573,7 @@ func (s *State) LoadFromClipboard(lines []string) {
 	s.Entries = logx.ParseLines(lines)
 	s.InputMode = input.ModeClipboard
 	s.FilterQuery = ""
+	s.ClearExpandedContext()
 	s.Refilter()
 	s.Cursor = 0
 	s.StatusMsg = ""
@@ -301,6 +588,7 @@ func (s *State) LoadFromFile(path string) error {
 	s.InputMode = input.ModeFile
 	s.FileName = path
 	s.FilterQuery = ""
+	s.ClearExpandedContext()
 	s.Refilter()
 	s.Cursor = 0
 	s.StatusMsg = ""
@@ -332,6 +620,36 @@ func (s *State) VisibleEntries() []logx.Entry {
 	return result
 }
 
+func (s *State) MatchedEntries() []logx.Entry {
+	result := make([]logx.Entry, 0, len(s.Filtered))
+	for _, idx := range s.Filtered {
+		if !s.ContextRows[idx] {
+			result = append(result, s.Entries[idx])
+		}
+	}
+	return result
+}
+
+func (s *State) MatchedEntryIndex(pos int) int {
+	for _, idx := range s.Filtered {
+		if s.ContextRows[idx] {
+			continue
+		}
+		if pos == 0 {
+			return idx
+		}
+		pos--
+	}
+	return -1
+}
+
+func (s *State) Templates() signal.TemplateCache {
+	if s.TemplateCache == nil {
+		s.TemplateCache = make(signal.TemplateCache)
+	}
+	return s.TemplateCache
+}
+
 func (s *State) HasNote(idx int) bool {
 	_, ok := s.Notes[idx]
 	return ok
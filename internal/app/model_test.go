@@ -0,0 +1,49 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kalayciburak/lx/internal/logx"
+)
+
+func TestScrollColumns(t *testing.T) {
+	s := newTestState("short", strings.Repeat("x", 50), strings.Repeat("日本語", 10))
+	tests := []struct {
+		name           string
+		delta, visible int
+		want           int
+	}{
+		{"scrolls right", 10, 20, 10},
+		{"stops when the widest message ends at the edge", 100, 20, 40},
+		{"wider view scrolls less", 0, 50, 10},
+		{"scrolls left", -4, 50, 6},
+		{"stops at the start", -100, 20, 0},
+		{"everything visible", 5, 80, 0},
+	}
+
+	for _, tt := range tests {
+		s.ScrollColumns(tt.delta, tt.visible)
+		if s.HScroll != tt.want {
+			t.Errorf("%s: ScrollColumns(%d, %d) = %d, want %d", tt.name, tt.delta, tt.visible, s.HScroll, tt.want)
+		}
+	}
+}
+
+func TestMessageWidth(t *testing.T) {
+	s := newTestState("short", strings.Repeat("日本語", 10))
+	if w := s.MessageWidth(); w != 60 {
+		t.Fatalf("MessageWidth() = %d, want 60", w)
+	}
+
+	s.AppendEntries(logx.ParseLines([]string{strings.Repeat("y", 100)}))
+	if w := s.MessageWidth(); w != 100 || s.WidestScanned != 3 {
+		t.Errorf("after append: MessageWidth() = %d scanned %d, want 100 and 3", w, s.WidestScanned)
+	}
+
+	s.FilterQuery = "short"
+	s.Refilter()
+	if w := s.MessageWidth(); w != 5 {
+		t.Errorf("after filtering: MessageWidth() = %d, want 5", w)
+	}
+}
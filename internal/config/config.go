@@ -0,0 +1,448 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/kalayciburak/lx/internal/logx"
+	"github.com/kalayciburak/lx/internal/signal"
+)
+
+const (
+	ProjectFileName = ".lx.toml"
+	UserFileName    = "config.toml"
+)
+
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+type Limits struct {
+	MaxCopyLines       int
+	MaxSelectLines     int
+	MaxTextFilterLines int
+	AsyncLoadingLines  int
+}
+
+type Config struct {
+	Paths         []string
+	Filter        string
+	Level         logx.Level
+	ContextBefore *int
+	ContextAfter  *int
+	Fields        logx.FieldMapping
+	Patterns      []*regexp.Regexp
+	LevelAliases  map[string]logx.Level
+	Limits        Limits
+	BurstWindows  []signal.BurstWindow
+	ThemeName     string
+	ColorProfile  string
+	Theme         map[string]string
+	KeyPreset     string
+	Keys          map[string][]string
+}
+
+func UserPath() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "lx", UserFileName)
+}
+
+func FindProject(dir string) string {
+	for {
+		path := filepath.Join(dir, ProjectFileName)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+func Layers(dir string) []string {
+	var paths []string
+	if path := UserPath(); path != "" {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			paths = append(paths, path)
+		}
+	}
+	if path := FindProject(dir); path != "" {
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+func LoadLayered(dir string) (*Config, error) {
+	return LoadFiles(Layers(dir))
+}
+
+func LoadFiles(paths []string) (*Config, error) {
+	cfg := &Config{}
+	for _, path := range paths {
+		layer, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Merge(layer)
+	}
+	return cfg, nil
+}
+
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := parseTOML(string(data))
+	if err != nil {
+		return nil, errors.New(path + ": " + err.Error())
+	}
+	cfg, err := decode(tree)
+	if err != nil {
+		return nil, errors.New(path + ": " + err.Error())
+	}
+	cfg.Paths = []string{path}
+	return cfg, nil
+}
+
+func (c *Config) Merge(o *Config) {
+	c.Paths = append(c.Paths, o.Paths...)
+	if o.Filter != "" {
+		c.Filter = o.Filter
+	}
+	if o.Level != logx.LevelUnknown {
+		c.Level = o.Level
+	}
+	if o.ContextBefore != nil {
+		c.ContextBefore = o.ContextBefore
+	}
+	if o.ContextAfter != nil {
+		c.ContextAfter = o.ContextAfter
+	}
+	c.Fields = logx.FieldMapping{
+		Message:   append(append([]string{}, o.Fields.Message...), c.Fields.Message...),
+		Level:     append(append([]string{}, o.Fields.Level...), c.Fields.Level...),
+		Timestamp: append(append([]string{}, o.Fields.Timestamp...), c.Fields.Timestamp...),
+	}
+	c.Patterns = append(append([]*regexp.Regexp{}, o.Patterns...), c.Patterns...)
+	for alias, level := range o.LevelAliases {
+		if c.LevelAliases == nil {
+			c.LevelAliases = make(map[string]logx.Level)
+		}
+		c.LevelAliases[alias] = level
+	}
+	overrideInt(&c.Limits.MaxCopyLines, o.Limits.MaxCopyLines)
+	overrideInt(&c.Limits.MaxSelectLines, o.Limits.MaxSelectLines)
+	overrideInt(&c.Limits.MaxTextFilterLines, o.Limits.MaxTextFilterLines)
+	overrideInt(&c.Limits.AsyncLoadingLines, o.Limits.AsyncLoadingLines)
+	if o.BurstWindows != nil {
+		c.BurstWindows = o.BurstWindows
+	}
+	if o.ThemeName != "" {
+		c.ThemeName = o.ThemeName
+	}
+	if o.ColorProfile != "" {
+		c.ColorProfile = o.ColorProfile
+	}
+	c.Theme = mergeStrings(c.Theme, o.Theme)
+	if o.KeyPreset != "" {
+		c.KeyPreset = o.KeyPreset
+	}
+	for action, keys := range o.Keys {
+		if c.Keys == nil {
+			c.Keys = make(map[string][]string)
+		}
+		c.Keys[action] = keys
+	}
+}
+
+func overrideInt(dst *int, src int) {
+	if src > 0 {
+		*dst = src
+	}
+}
+
+func mergeStrings(dst, src map[string]string) map[string]string {
+	for k, v := range src {
+		if dst == nil {
+			dst = make(map[string]string)
+		}
+		dst[k] = v
+	}
+	return dst
+}
+
+func decode(tree map[string]any) (*Config, error) {
+	cfg := &Config{}
+	for _, key := range sortedKeys(tree) {
+		table, ok := tree[key].(map[string]any)
+		if !ok {
+			return nil, errors.New(key + " must be a table")
+		}
+		var err error
+		switch key {
+		case "filter":
+			err = decodeFilter(table, cfg)
+		case "fields":
+			err = decodeFields(table, &cfg.Fields)
+		case "parser":
+			err = decodeParser(table, cfg)
+		case "limits":
+			err = decodeLimits(table, &cfg.Limits)
+		case "signals":
+			err = decodeSignals(table, cfg)
+		case "theme":
+			err = decodeTheme(table, cfg)
+		case "keys":
+			err = decodeKeys(table, cfg)
+		default:
+			err = errors.New("unknown key " + key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+func decodeFilter(table map[string]any, cfg *Config) error {
+	for _, key := range []string{"context", "before", "after"} {
+		v, ok := table[key]
+		if !ok {
+			continue
+		}
+		n, ok := v.(int64)
+		if !ok || n < 0 {
+			return errors.New("filter." + key + ": expected a non-negative integer")
+		}
+		lines := int(n)
+		if key != "after" {
+			cfg.ContextBefore = &lines
+		}
+		if key != "before" {
+			cfg.ContextAfter = &lines
+		}
+	}
+	for _, key := range sortedKeys(table) {
+		switch key {
+		case "before", "after", "context":
+			continue
+		}
+		s, ok := table[key].(string)
+		if !ok {
+			return errors.New("filter." + key + ": expected a string")
+		}
+		switch key {
+		case "query":
+			cfg.Filter = s
+		case "level":
+			level, ok := logx.ParseLevel(s)
+			if !ok {
+				return errors.New("filter.level: unknown level " + strconv.Quote(s))
+			}
+			cfg.Level = level
+		default:
+			return errors.New("unknown key filter." + key)
+		}
+	}
+	return nil
+}
+
+func decodeFields(table map[string]any, m *logx.FieldMapping) error {
+	for _, key := range sortedKeys(table) {
+		paths, err := stringList(table[key])
+		if err != nil {
+			return errors.New("fields." + key + ": " + err.Error())
+		}
+		switch key {
+		case "message":
+			m.Message = paths
+		case "level":
+			m.Level = paths
+		case "timestamp":
+			m.Timestamp = paths
+		default:
+			return errors.New("unknown key fields." + key)
+		}
+	}
+	return nil
+}
+
+func decodeParser(table map[string]any, cfg *Config) error {
+	for _, key := range sortedKeys(table) {
+		switch key {
+		case "patterns":
+			exprs, err := stringList(table[key])
+			if err != nil {
+				return errors.New("parser.patterns: " + err.Error())
+			}
+			for _, expr := range exprs {
+				re, err := logx.CompileLinePattern(expr)
+				if err != nil {
+					return errors.New("parser.patterns: " + err.Error())
+				}
+				cfg.Patterns = append(cfg.Patterns, re)
+			}
+		case "levels":
+			levels, ok := table[key].(map[string]any)
+			if !ok {
+				return errors.New("parser.levels must be a table")
+			}
+			cfg.LevelAliases = make(map[string]logx.Level, len(levels))
+			for _, alias := range sortedKeys(levels) {
+				name, ok := levels[alias].(string)
+				if !ok {
+					return errors.New("parser.levels." + alias + ": expected a string")
+				}
+				level, ok := logx.ParseLevel(name)
+				if !ok {
+					return errors.New("parser.levels." + alias + ": unknown level " + strconv.Quote(name))
+				}
+				cfg.LevelAliases[alias] = level
+			}
+		default:
+			return errors.New("unknown key parser." + key)
+		}
+	}
+	return nil
+}
+
+func decodeLimits(table map[string]any, l *Limits) error {
+	for _, key := range sortedKeys(table) {
+		n, ok := table[key].(int64)
+		if !ok || n <= 0 {
+			return errors.New("limits." + key + ": expected a positive integer")
+		}
+		switch key {
+		case "max_copy_lines":
+			l.MaxCopyLines = int(n)
+		case "max_select_lines":
+			l.MaxSelectLines = int(n)
+		case "max_text_filter_lines":
+			l.MaxTextFilterLines = int(n)
+		case "async_loading_lines":
+			l.AsyncLoadingLines = int(n)
+		default:
+			return errors.New("unknown key limits." + key)
+		}
+	}
+	return nil
+}
+
+func decodeSignals(table map[string]any, cfg *Config) error {
+	for _, key := range sortedKeys(table) {
+		switch key {
+		case "burst_windows":
+			list, ok := table[key].([]any)
+			if !ok || len(list) == 0 {
+				return errors.New("signals.burst_windows: expected a list of [seconds, count] pairs")
+			}
+			cfg.BurstWindows = make([]signal.BurstWindow, 0, len(list))
+			for _, item := range list {
+				pair, ok := item.([]any)
+				if !ok || len(pair) != 2 {
+					return errors.New("signals.burst_windows: expected a list of [seconds, count] pairs")
+				}
+				secs, ok1 := pair[0].(int64)
+				count, ok2 := pair[1].(int64)
+				if !ok1 || !ok2 || secs <= 0 || count <= 0 {
+					return errors.New("signals.burst_windows: seconds and count must be positive integers")
+				}
+				cfg.BurstWindows = append(cfg.BurstWindows, signal.BurstWindow{Seconds: int(secs), Threshold: int(count)})
+			}
+		default:
+			return errors.New("unknown key signals." + key)
+		}
+	}
+	return nil
+}
+
+func decodeTheme(table map[string]any, cfg *Config) error {
+	cfg.Theme = make(map[string]string, len(table))
+	for _, key := range sortedKeys(table) {
+		s, ok := table[key].(string)
+		switch key {
+		case "name", "profile":
+			if !ok || s == "" {
+				return errors.New("theme." + key + ": expected a string")
+			}
+			if key == "name" {
+				cfg.ThemeName = s
+			} else {
+				cfg.ColorProfile = s
+			}
+			continue
+		}
+		if !ok || !isColor(s) {
+			return errors.New("theme." + key + ": expected #RRGGBB or an ANSI color number")
+		}
+		cfg.Theme[key] = s
+	}
+	return nil
+}
+
+func decodeKeys(table map[string]any, cfg *Config) error {
+	cfg.Keys = make(map[string][]string, len(table))
+	for _, key := range sortedKeys(table) {
+		if key == "preset" {
+			s, ok := table[key].(string)
+			if !ok {
+				return errors.New("keys.preset: expected a string")
+			}
+			cfg.KeyPreset = s
+			continue
+		}
+		keys, err := stringList(table[key])
+		if err != nil || len(keys) == 0 {
+			return errors.New("keys." + key + ": expected a key or list of keys")
+		}
+		cfg.Keys[key] = keys
+	}
+	return nil
+}
+
+func isColor(s string) bool {
+	if hexColorPattern.MatchString(s) {
+		return true
+	}
+	n, err := strconv.Atoi(s)
+	return err == nil && n >= 0 && n <= 255
+}
+
+func stringList(val any) ([]string, error) {
+	switch v := val.(type) {
+	case string:
+		return []string{v}, nil
+	case []any:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, errors.New("expected a string or list of strings")
+			}
+			list = append(list, s)
+		}
+		return list, nil
+	}
+	return nil, errors.New("expected a string or list of strings")
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,265 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kalayciburak/lx/internal/logx"
+	"github.com/kalayciburak/lx/internal/signal"
+)
+
+func TestParseTOML(t *testing.T) {
+	src := `
+# project settings
+title = "lx # not a comment"
+[fields]
+message = ["event", 'log.message'] # trailing
+level = "log.level"
+timestamp = [
+  "eventTime",
+  "@timestamp",
+]
+[signal.burst]
+enabled = true
+ratio = 0.5
+count = 1_000
+[signal]
+rate.window = 60
+`
+	tree, err := parseTOML(src)
+	if err != nil {
+		t.Fatalf("parseTOML() error = %v", err)
+	}
+
+	want := map[string]any{
+		"title": "lx # not a comment",
+		"fields": map[string]any{
+			"message":   []any{"event", "log.message"},
+			"level":     "log.level",
+			"timestamp": []any{"eventTime", "@timestamp"},
+		},
+		"signal": map[string]any{
+			"burst": map[string]any{"enabled": true, "ratio": 0.5, "count": int64(1000)},
+			"rate":  map[string]any{"window": int64(60)},
+		},
+	}
+	if !reflect.DeepEqual(tree, want) {
+		t.Errorf("parseTOML() = %#v, want %#v", tree, want)
+	}
+}
+
+func TestParseTOMLErrors(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"key", "line 1: expected key = value"},
+		{"a = 1\na = 2", "line 2: duplicate key a"},
+		{"a = \"open", "line 1: unterminated string"},
+		{"a = [1, 2", "line 1: unterminated array"},
+		{"[[tables]]", "line 1: unsupported table header [[tables]]"},
+		{"a = nope", "line 1: invalid value nope"},
+		{"[filter]\nquery = \"a\"\n[filter]\nlevel = \"warn\"", "line 3: duplicate table [filter]"},
+		{"[parser.levels]\nw = \"warn\"\n[parser.levels]", "line 3: duplicate table [parser.levels]"},
+		{"[parser]\nlevels.w = \"warn\"\n[parser.levels]", "line 3: table [parser.levels] already defined by dotted keys"},
+		{"[parser.levels]\n[parser]\nlevels.w = \"warn\"", "line 3: table [parser.levels] already defined"},
+		{"a = {b = 1}", "line 1: inline tables are not supported"},
+		{"a = \"\"\"text\"\"\"", "line 1: multi-line strings are not supported"},
+	}
+	for _, tt := range tests {
+		_, err := parseTOML(tt.src)
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("parseTOML(%q) error = %v, want %q", tt.src, err, tt.want)
+		}
+	}
+}
+
+func TestLoadProject(t *testing.T) {
+	root := t.TempDir()
+	sub := filepath.Join(root, "svc", "api")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(root, ProjectFileName)
+	if err := os.WriteFile(path, []byte("[fields]\nmessage = \"event\"\nlevel = [\"loglevel\", \"log.level\"]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	found := FindProject(sub)
+	if found != path {
+		t.Fatalf("FindProject(%q) = %q, want %q", sub, found, path)
+	}
+	cfg, err := Load(found)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !reflect.DeepEqual(cfg.Fields.Message, []string{"event"}) || !reflect.DeepEqual(cfg.Fields.Level, []string{"loglevel", "log.level"}) {
+		t.Errorf("Load() fields = %+v", cfg.Fields)
+	}
+
+	if err := os.WriteFile(path, []byte("[fields]\nmesage = \"event\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "unknown key fields.mesage") {
+		t.Errorf("Load(typo) error = %v, want unknown key fields.mesage", err)
+	}
+}
+
+func TestLoadLayered(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(root, "xdg"))
+	user := UserPath()
+	if err := os.MkdirAll(filepath.Dir(user), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	userSrc := `
+[filter]
+query = "timeout"
+level = "warn"
+context = 3
+
+[fields]
+message = "event"
+
+[limits]
+max_copy_lines = 2000
+max_select_lines = 4000
+
+[theme]
+name = "light"
+profile = "256"
+accent = "#00AAFF"
+
+[keys]
+preset = "vim"
+down = ["j", "ctrl+n"]
+quit = "ctrl+q"
+`
+	projectSrc := `
+[filter]
+level = "error"
+after = 8
+
+[fields]
+message = "text"
+
+[parser]
+patterns = ['^(?P<level>\w+): (?P<message>.*)$']
+
+[parser.levels]
+sev5 = "critical"
+
+[limits]
+max_copy_lines = 500
+
+[signals]
+burst_windows = [[5, 3], [60, 20]]
+
+[theme]
+accent = "208"
+
+[keys]
+quit = ["q", "ctrl+q"]
+`
+	if err := os.WriteFile(user, []byte(userSrc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ProjectFileName), []byte(projectSrc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadLayered(root)
+	if err != nil {
+		t.Fatalf("LoadLayered() error = %v", err)
+	}
+	if len(cfg.Paths) != 2 || cfg.Paths[0] != user {
+		t.Errorf("Paths = %v, want user config first", cfg.Paths)
+	}
+	if cfg.Filter != "timeout" || cfg.Level != logx.LevelError {
+		t.Errorf("filter = %q %v, want timeout ERROR", cfg.Filter, cfg.Level)
+	}
+	if cfg.ContextBefore == nil || *cfg.ContextBefore != 3 || cfg.ContextAfter == nil || *cfg.ContextAfter != 8 {
+		t.Errorf("context = %v %v, want -B3 -A8", cfg.ContextBefore, cfg.ContextAfter)
+	}
+	if !reflect.DeepEqual(cfg.Fields.Message, []string{"text", "event"}) {
+		t.Errorf("Fields.Message = %v, want project keys first", cfg.Fields.Message)
+	}
+	if cfg.Limits.MaxCopyLines != 500 || cfg.Limits.MaxSelectLines != 4000 {
+		t.Errorf("Limits = %+v", cfg.Limits)
+	}
+	if len(cfg.Patterns) != 1 || cfg.LevelAliases["sev5"] != logx.LevelCritical {
+		t.Errorf("parser = %v %v", cfg.Patterns, cfg.LevelAliases)
+	}
+	if !reflect.DeepEqual(cfg.BurstWindows, []signal.BurstWindow{{Seconds: 5, Threshold: 3}, {Seconds: 60, Threshold: 20}}) {
+		t.Errorf("BurstWindows = %v", cfg.BurstWindows)
+	}
+	if cfg.ThemeName != "light" || cfg.ColorProfile != "256" || cfg.Theme["accent"] != "208" {
+		t.Errorf("theme = %q %q %v", cfg.ThemeName, cfg.ColorProfile, cfg.Theme)
+	}
+	wantKeys := map[string][]string{"down": {"j", "ctrl+n"}, "quit": {"q", "ctrl+q"}}
+	if cfg.KeyPreset != "vim" || !reflect.DeepEqual(cfg.Keys, wantKeys) {
+		t.Errorf("keys = %q %v, want vim %v", cfg.KeyPreset, cfg.Keys, wantKeys)
+	}
+}
+
+func TestFilterContext(t *testing.T) {
+	tests := []struct {
+		user, project string
+		before, after int
+	}{
+		{"context = 3\nbefore = 1", "", 1, 3},
+		{"after = 2\ncontext = 5", "", 5, 2},
+		{"context = 3", "context = 0", 0, 0},
+		{"context = 3", "before = 0", 0, 3},
+		{"context = 3", "level = \"warn\"", 3, 3},
+	}
+
+	for _, tt := range tests {
+		var cfg Config
+		for _, src := range []string{tt.user, tt.project} {
+			tree, err := parseTOML("[filter]\n" + src)
+			if err != nil {
+				t.Fatalf("parseTOML(%q) error = %v", src, err)
+			}
+			layer, err := decode(tree)
+			if err != nil {
+				t.Fatalf("decode(%q) error = %v", src, err)
+			}
+			cfg.Merge(layer)
+		}
+		if cfg.ContextBefore == nil || cfg.ContextAfter == nil || *cfg.ContextBefore != tt.before || *cfg.ContextAfter != tt.after {
+			t.Errorf("%q then %q: context = %v %v, want -B%d -A%d", tt.user, tt.project, cfg.ContextBefore, cfg.ContextAfter, tt.before, tt.after)
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{"[filter]\nlevel = \"loud\"", `filter.level: unknown level "loud"`},
+		{"[parser]\npatterns = ['^(\\w+)$']", "parser.patterns: pattern needs a message, level or timestamp named group"},
+		{"[filter]\ncontext = \"3\"", "filter.context: expected a non-negative integer"},
+		{"[filter]\nbefore = -1", "filter.before: expected a non-negative integer"},
+		{"[limits]\nmax_copy_lines = 0", "limits.max_copy_lines: expected a positive integer"},
+		{"[signals]\nburst_windows = [[10]]", "signals.burst_windows: expected a list of [seconds, count] pairs"},
+		{"[theme]\naccent = \"orange\"", "theme.accent: expected #RRGGBB or an ANSI color number"},
+		{"theme = 1", "theme must be a table"},
+		{"[theme]\nname = 2", "theme.name: expected a string"},
+		{"[keys]\ndown = []", "keys.down: expected a key or list of keys"},
+		{"[colors]\naccent = \"#fff\"", "unknown key colors"},
+	}
+	for _, tt := range tests {
+		tree, err := parseTOML(tt.src)
+		if err != nil {
+			t.Fatalf("parseTOML(%q) error = %v", tt.src, err)
+		}
+		if _, err := decode(tree); err == nil || err.Error() != tt.want {
+			t.Errorf("decode(%q) error = %v, want %q", tt.src, err, tt.want)
+		}
+	}
+}
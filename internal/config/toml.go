@@ -0,0 +1,311 @@
+package config
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+type ParseError struct {
+	Line int
+	Msg  string
+}
+
+func (e *ParseError) Error() string {
+	return "line " + strconv.Itoa(e.Line) + ": " + e.Msg
+}
+
+func parseTOML(data string) (map[string]any, error) {
+	root := make(map[string]any)
+	current := root
+	headers := make(map[string]bool)
+	dotted := make(map[string]bool)
+	currentName := ""
+	lines := strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		lineNum := i + 1
+		line := strings.TrimSpace(stripComment(lines[i]))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if strings.HasPrefix(line, "[[") || !strings.HasSuffix(line, "]") {
+				return nil, &ParseError{lineNum, "unsupported table header " + line}
+			}
+			path, err := splitKey(strings.TrimSpace(line[1 : len(line)-1]))
+			if err != nil {
+				return nil, &ParseError{lineNum, err.Error()}
+			}
+			name := strings.Join(path, ".")
+			if headers[name] {
+				return nil, &ParseError{lineNum, "duplicate table [" + name + "]"}
+			}
+			if dotted[name] {
+				return nil, &ParseError{lineNum, "table [" + name + "] already defined by dotted keys"}
+			}
+			table, err := descend(root, path)
+			if err != nil {
+				return nil, &ParseError{lineNum, err.Error()}
+			}
+			headers[name] = true
+			current, currentName = table, name
+			continue
+		}
+
+		eq := strings.Index(line, "=")
+		if eq < 0 {
+			return nil, &ParseError{lineNum, "expected key = value"}
+		}
+		path, err := splitKey(strings.TrimSpace(line[:eq]))
+		if err != nil {
+			return nil, &ParseError{lineNum, err.Error()}
+		}
+		raw := strings.TrimSpace(line[eq+1:])
+
+		for strings.HasPrefix(raw, "[") && !arrayClosed(raw) && i+1 < len(lines) {
+			i++
+			raw += " " + strings.TrimSpace(stripComment(lines[i]))
+		}
+
+		val, rest, err := parseValue(raw)
+		if err != nil {
+			return nil, &ParseError{lineNum, err.Error()}
+		}
+		if strings.TrimSpace(rest) != "" {
+			return nil, &ParseError{lineNum, "unexpected " + strings.TrimSpace(rest)}
+		}
+
+		table, err := descend(current, path[:len(path)-1])
+		if err != nil {
+			return nil, &ParseError{lineNum, err.Error()}
+		}
+		prefix := currentName
+		for _, part := range path[:len(path)-1] {
+			if prefix != "" {
+				prefix += "."
+			}
+			prefix += part
+			if headers[prefix] {
+				return nil, &ParseError{lineNum, "table [" + prefix + "] already defined"}
+			}
+			dotted[prefix] = true
+		}
+		key := path[len(path)-1]
+		if _, exists := table[key]; exists {
+			return nil, &ParseError{lineNum, "duplicate key " + key}
+		}
+		table[key] = val
+	}
+	return root, nil
+}
+
+func descend(table map[string]any, path []string) (map[string]any, error) {
+	for _, key := range path {
+		next, ok := table[key]
+		if !ok {
+			child := make(map[string]any)
+			table[key] = child
+			table = child
+			continue
+		}
+		child, ok := next.(map[string]any)
+		if !ok {
+			return nil, errors.New(key + " is not a table")
+		}
+		table = child
+	}
+	return table, nil
+}
+
+func splitKey(s string) ([]string, error) {
+	var parts []string
+	for s != "" {
+		s = strings.TrimSpace(s)
+		var part string
+		if s[0] == '"' || s[0] == '\'' {
+			val, rest, err := parseString(s)
+			if err != nil {
+				return nil, err
+			}
+			part, s = val, rest
+		} else {
+			end := strings.IndexByte(s, '.')
+			if end < 0 {
+				end = len(s)
+			}
+			part = strings.TrimSpace(s[:end])
+			s = s[end:]
+			if part == "" || strings.ContainsAny(part, " \t") {
+				return nil, errors.New("invalid key " + strconv.Quote(part))
+			}
+		}
+		parts = append(parts, part)
+		s = strings.TrimSpace(s)
+		if s != "" {
+			if s[0] != '.' {
+				return nil, errors.New("invalid key near " + s)
+			}
+			s = s[1:]
+		}
+	}
+	if len(parts) == 0 {
+		return nil, errors.New("empty key")
+	}
+	return parts, nil
+}
+
+func parseValue(s string) (any, string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, "", errors.New("missing value")
+	}
+
+	switch s[0] {
+	case '"', '\'':
+		if strings.HasPrefix(s, `"""`) || strings.HasPrefix(s, "'''") {
+			return nil, "", errors.New("multi-line strings are not supported")
+		}
+		return parseString(s)
+	case '{':
+		return nil, "", errors.New("inline tables are not supported")
+	case '[':
+		var values []any
+		s = strings.TrimSpace(s[1:])
+		for {
+			if s == "" {
+				return nil, "", errors.New("unterminated array")
+			}
+			if s[0] == ']' {
+				return values, s[1:], nil
+			}
+			val, rest, err := parseValue(s)
+			if err != nil {
+				return nil, "", err
+			}
+			values = append(values, val)
+			s = strings.TrimSpace(rest)
+			if s == "" {
+				return nil, "", errors.New("unterminated array")
+			}
+			if strings.HasPrefix(s, ",") {
+				s = strings.TrimSpace(s[1:])
+			} else if !strings.HasPrefix(s, "]") {
+				return nil, "", errors.New("expected , or ] in array")
+			}
+		}
+	}
+
+	end := strings.IndexAny(s, ",]")
+	if end < 0 {
+		end = len(s)
+	}
+	token, rest := strings.TrimSpace(s[:end]), s[end:]
+	switch token {
+	case "true":
+		return true, rest, nil
+	case "false":
+		return false, rest, nil
+	}
+	clean := strings.ReplaceAll(token, "_", "")
+	if n, err := strconv.ParseInt(clean, 10, 64); err == nil {
+		return n, rest, nil
+	}
+	if f, err := strconv.ParseFloat(clean, 64); err == nil {
+		return f, rest, nil
+	}
+	return nil, "", errors.New("invalid value " + token)
+}
+
+func parseString(s string) (string, string, error) {
+	quote := s[0]
+	if quote == '\'' {
+		end := strings.IndexByte(s[1:], '\'')
+		if end < 0 {
+			return "", "", errors.New("unterminated string")
+		}
+		return s[1 : end+1], s[end+2:], nil
+	}
+
+	var sb strings.Builder
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"':
+			return sb.String(), s[i+1:], nil
+		case c == '\\' && i+1 < len(s):
+			i++
+			switch s[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case 'r':
+				sb.WriteByte('\r')
+			case '"', '\\':
+				sb.WriteByte(s[i])
+			case 'u':
+				if i+4 < len(s) {
+					if r, err := strconv.ParseUint(s[i+1:i+5], 16, 32); err == nil {
+						sb.WriteRune(rune(r))
+						i += 4
+						continue
+					}
+				}
+				return "", "", errors.New("invalid unicode escape")
+			default:
+				return "", "", errors.New("invalid escape \\" + string(s[i]))
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", "", errors.New("unterminated string")
+}
+
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+func arrayClosed(s string) bool {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+			if depth == 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package diff
+
+type Kind int
+
+const (
+	Same Kind = iota
+	Changed
+	Removed
+	Added
+)
+
+type Row struct {
+	Kind Kind
+	A    int
+	B    int
+}
+
+type Hunk struct {
+	Start int
+	End   int
+}
+
+func Align(edits []Edit) []Row {
+	var rows []Row
+	for i := 0; i < len(edits); {
+		if edits[i].Op == Equal {
+			rows = append(rows, Row{Kind: Same, A: edits[i].A, B: edits[i].B})
+			i++
+			continue
+		}
+		var dels, ins []int
+		for ; i < len(edits) && edits[i].Op != Equal; i++ {
+			if edits[i].Op == Delete {
+				dels = append(dels, edits[i].A)
+			} else {
+				ins = append(ins, edits[i].B)
+			}
+		}
+		for j := 0; j < len(dels) || j < len(ins); j++ {
+			switch {
+			case j < len(dels) && j < len(ins):
+				rows = append(rows, Row{Kind: Changed, A: dels[j], B: ins[j]})
+			case j < len(dels):
+				rows = append(rows, Row{Kind: Removed, A: dels[j], B: -1})
+			default:
+				rows = append(rows, Row{Kind: Added, A: -1, B: ins[j]})
+			}
+		}
+	}
+	return rows
+}
+
+func Hunks(rows []Row) []Hunk {
+	var hunks []Hunk
+	for i := 0; i < len(rows); i++ {
+		if rows[i].Kind == Same {
+			continue
+		}
+		start := i
+		for i < len(rows) && rows[i].Kind != Same {
+			i++
+		}
+		hunks = append(hunks, Hunk{Start: start, End: i})
+	}
+	return hunks
+}
@@ -0,0 +1,207 @@
+package diff
+
+type Op int
+
+const (
+	Equal Op = iota
+	Delete
+	Insert
+)
+
+type Edit struct {
+	Op Op
+	A  int
+	B  int
+}
+
+const MaxEdits = 2000
+
+func Lines(a, b []string) []Edit {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var edits []Edit
+	for i := 0; i < prefix; i++ {
+		edits = append(edits, Edit{Op: Equal, A: i, B: i})
+	}
+	edits = shift(edits, middle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]), prefix, prefix)
+	for i := suffix; i > 0; i-- {
+		edits = append(edits, Edit{Op: Equal, A: len(a) - i, B: len(b) - i})
+	}
+	return edits
+}
+
+func middle(a, b []string) []Edit {
+	if len(a) == 0 || len(b) == 0 {
+		return replace(a, b)
+	}
+	if edits, ok := myers(a, b); ok {
+		return edits
+	}
+	if anchors := uniqueAnchors(a, b); len(anchors) > 0 {
+		var edits []Edit
+		ai, bi := 0, 0
+		for _, p := range anchors {
+			edits = shift(edits, Lines(a[ai:p.A], b[bi:p.B]), ai, bi)
+			edits = append(edits, p)
+			ai, bi = p.A+1, p.B+1
+		}
+		return shift(edits, Lines(a[ai:], b[bi:]), ai, bi)
+	}
+	ha, hb := len(a)/2, len(b)/2
+	edits := Lines(a[:ha], b[:hb])
+	return shift(edits, Lines(a[ha:], b[hb:]), ha, hb)
+}
+
+func shift(edits, more []Edit, da, db int) []Edit {
+	for _, e := range more {
+		if e.Op != Insert {
+			e.A += da
+		}
+		if e.Op != Delete {
+			e.B += db
+		}
+		edits = append(edits, e)
+	}
+	return edits
+}
+
+func replace(a, b []string) []Edit {
+	edits := make([]Edit, 0, len(a)+len(b))
+	for i := range a {
+		edits = append(edits, Edit{Op: Delete, A: i, B: -1})
+	}
+	for j := range b {
+		edits = append(edits, Edit{Op: Insert, A: -1, B: j})
+	}
+	return edits
+}
+
+func uniqueAnchors(a, b []string) []Edit {
+	countA := make(map[string]int, len(a))
+	for _, line := range a {
+		countA[line]++
+	}
+	countB := make(map[string]int, len(b))
+	posB := make(map[string]int, len(b))
+	for j, line := range b {
+		countB[line]++
+		posB[line] = j
+	}
+
+	var pairs []Edit
+	for i, line := range a {
+		if countA[line] == 1 && countB[line] == 1 {
+			pairs = append(pairs, Edit{Op: Equal, A: i, B: posB[line]})
+		}
+	}
+
+	var tails []int
+	prev := make([]int, len(pairs))
+	for i, p := range pairs {
+		lo, hi := 0, len(tails)
+		for lo < hi {
+			mid := (lo + hi) / 2
+			if pairs[tails[mid]].B < p.B {
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+		prev[i] = -1
+		if lo > 0 {
+			prev[i] = tails[lo-1]
+		}
+		if lo == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[lo] = i
+		}
+	}
+	if len(tails) == 0 {
+		return nil
+	}
+	anchors := make([]Edit, len(tails))
+	for i, k := len(tails)-1, tails[len(tails)-1]; i >= 0; i, k = i-1, prev[k] {
+		anchors[i] = pairs[k]
+	}
+	return anchors
+}
+
+func myers(a, b []string) ([]Edit, bool) {
+	n, m := len(a), len(b)
+	limit := n + m
+	if limit > MaxEdits {
+		limit = MaxEdits
+	}
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+	var trace [][]int
+
+	for d := 0; d <= limit; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d-1:offset+d+2]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, n, m), true
+			}
+		}
+	}
+	return nil, false
+}
+
+func backtrack(trace [][]int, x, y int) []Edit {
+	var edits []Edit
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		at := func(k int) int { return v[k+d+1] }
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := 0
+		if d > 0 {
+			prevX = at(prevK)
+		}
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			edits = append(edits, Edit{Op: Equal, A: x, B: y})
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			y--
+			edits = append(edits, Edit{Op: Insert, A: -1, B: y})
+		} else {
+			x--
+			edits = append(edits, Edit{Op: Delete, A: x, B: -1})
+		}
+	}
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
@@ -0,0 +1,120 @@
+package diff
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func apply(a, b []string, edits []Edit) ([]string, []string) {
+	var gotA, gotB []string
+	for _, e := range edits {
+		switch e.Op {
+		case Equal:
+			if a[e.A] != b[e.B] {
+				return nil, nil
+			}
+			gotA = append(gotA, a[e.A])
+			gotB = append(gotB, b[e.B])
+		case Delete:
+			gotA = append(gotA, a[e.A])
+		case Insert:
+			gotB = append(gotB, b[e.B])
+		}
+	}
+	return gotA, gotB
+}
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		changes int
+	}{
+		{"", "", 0},
+		{"a b c", "a b c", 0},
+		{"", "a b", 2},
+		{"a b", "", 2},
+		{"a b c a b b a", "c b a b a c", 5},
+		{"a x c d", "a y c d e", 3},
+		{"start load fail stop", "start load retry ok stop", 3},
+	}
+
+	for _, tt := range tests {
+		a, b := strings.Fields(tt.a), strings.Fields(tt.b)
+		edits := Lines(a, b)
+		if changes := countChanges(edits); changes != tt.changes {
+			t.Errorf("Lines(%q, %q) = %d changes, want %d", tt.a, tt.b, changes, tt.changes)
+		}
+		gotA, gotB := apply(a, b, edits)
+		if strings.Join(gotA, " ") != tt.a || strings.Join(gotB, " ") != tt.b {
+			t.Errorf("Lines(%q, %q) does not reproduce inputs: %v / %v", tt.a, tt.b, gotA, gotB)
+		}
+	}
+}
+
+func countChanges(edits []Edit) int {
+	changes := 0
+	for _, e := range edits {
+		if e.Op != Equal {
+			changes++
+		}
+	}
+	return changes
+}
+
+func numbered(prefix string, n int) []string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = prefix + strconv.Itoa(i)
+	}
+	return lines
+}
+
+func TestLinesPastMaxEdits(t *testing.T) {
+	everyThird := numbered("line ", 4000)
+	for i := 1; i < len(everyThird)-1; i += 3 {
+		everyThird[i] = "changed " + strconv.Itoa(i)
+	}
+
+	tests := []struct {
+		name    string
+		a, b    []string
+		changes int
+	}{
+		{"nothing in common", numbered("a ", MaxEdits), numbered("b ", MaxEdits), 2 * MaxEdits},
+		{"repeated lines", strings.Fields(strings.Repeat("a ", MaxEdits)), strings.Fields(strings.Repeat("b ", MaxEdits)), 2 * MaxEdits},
+		{"unique anchors", numbered("line ", 4000), everyThird, 2 * 1333},
+		{"replaced head", numbered("line ", 3000), append(numbered("new ", 1200), numbered("line ", 3000)[1200:]...), 2 * 1200},
+		{"only inserts", numbered("line ", 10), numbered("line ", 3000), 2990},
+	}
+
+	for _, tt := range tests {
+		edits := Lines(tt.a, tt.b)
+		if changes := countChanges(edits); changes != tt.changes {
+			t.Errorf("%s: %d changes, want %d", tt.name, changes, tt.changes)
+		}
+		gotA, gotB := apply(tt.a, tt.b, edits)
+		if !reflect.DeepEqual(gotA, tt.a) || !reflect.DeepEqual(gotB, tt.b) {
+			t.Errorf("%s: edits do not reproduce inputs", tt.name)
+		}
+	}
+}
+
+func TestAlign(t *testing.T) {
+	edits := Lines(strings.Fields("start load fail stop"), strings.Fields("start load retry ok stop"))
+	rows := Align(edits)
+	want := []Row{
+		{Kind: Same, A: 0, B: 0},
+		{Kind: Same, A: 1, B: 1},
+		{Kind: Changed, A: 2, B: 2},
+		{Kind: Added, A: -1, B: 3},
+		{Kind: Same, A: 3, B: 4},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("Align = %v, want %v", rows, want)
+	}
+	if hunks := Hunks(rows); !reflect.DeepEqual(hunks, []Hunk{{Start: 2, End: 4}}) {
+		t.Errorf("Hunks = %v", hunks)
+	}
+}
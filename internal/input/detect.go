@@ -11,6 +11,8 @@ const (
 	ModeClipboard Mode = iota
 	ModeFile
 	ModePipe
+	ModeListen
+	ModeCommand
 )
 
 func (m Mode) String() string {
@@ -19,6 +21,10 @@ func (m Mode) String() string {
 		return "FILE"
 	case ModePipe:
 		return "PIPE"
+	case ModeListen:
+		return "LISTEN"
+	case ModeCommand:
+		return "RUN"
 	default:
 		return "CLIPBOARD"
 	}
@@ -0,0 +1,112 @@
+package input
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+)
+
+const maxJournalField = 64 * 1024 * 1024
+
+var journalExportMarkers = []string{"__CURSOR=", "__REALTIME_TIMESTAMP="}
+
+func IsJournalExport(prefix []byte) bool {
+	for _, marker := range journalExportMarkers {
+		if bytes.HasPrefix(prefix, []byte(marker)) {
+			return true
+		}
+	}
+	return false
+}
+
+func ReadJournalExport(r io.Reader) ([]string, error) {
+	var lines []string
+	err := DecodeJournalExport(bufio.NewReader(r), func(line string) {
+		lines = append(lines, line)
+	})
+	return lines, err
+}
+
+func DecodeJournalExport(r *bufio.Reader, emit func(string)) error {
+	record := make(map[string]string)
+	flush := func() {
+		if len(record) == 0 {
+			return
+		}
+		if b, err := json.Marshal(record); err == nil {
+			emit(string(b))
+		}
+		record = make(map[string]string)
+	}
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				if key, val, ok := strings.Cut(line, "="); ok {
+					record[key] = val
+				}
+				flush()
+				return nil
+			}
+			return err
+		}
+		line = strings.TrimSuffix(line, "\n")
+
+		if line == "" {
+			flush()
+			continue
+		}
+		if key, val, ok := strings.Cut(line, "="); ok {
+			record[key] = val
+			continue
+		}
+
+		var size uint64
+		if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+			return err
+		}
+		if size > maxJournalField {
+			return errors.New("journal export: field " + line + " too large")
+		}
+		data := make([]byte, size)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return err
+		}
+		if b, err := r.ReadByte(); err != nil || b != '\n' {
+			return errors.New("journal export: malformed binary field " + line)
+		}
+		record[line] = string(data)
+	}
+}
+
+func peekJournalExport(r *bufio.Reader) bool {
+	if _, err := r.Peek(1); err != nil {
+		return false
+	}
+	for {
+		buffered, _ := r.Peek(r.Buffered())
+		if IsJournalExport(buffered) {
+			return true
+		}
+		if !isJournalMarkerPrefix(buffered) {
+			return false
+		}
+		if _, err := r.Peek(len(buffered) + 1); err != nil {
+			return false
+		}
+	}
+}
+
+func isJournalMarkerPrefix(b []byte) bool {
+	for _, marker := range journalExportMarkers {
+		if len(b) < len(marker) && strings.HasPrefix(marker, string(b)) {
+			return true
+		}
+	}
+	return false
+}
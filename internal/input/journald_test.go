@@ -0,0 +1,92 @@
+package input
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestReadJournalExport(t *testing.T) {
+	var buf bytes.Buffer
+	buf.WriteString("__CURSOR=s=1\n__REALTIME_TIMESTAMP=1714564800123456\nPRIORITY=3\n_SYSTEMD_UNIT=nginx.service\n")
+	buf.WriteString("MESSAGE\n")
+	binary.Write(&buf, binary.LittleEndian, uint64(11))
+	buf.WriteString("bad\nrequest\n")
+	buf.WriteString("\n")
+	buf.WriteString("__CURSOR=s=2\nMESSAGE=second\n")
+
+	lines, err := ReadJournalExport(&buf)
+	if err != nil {
+		t.Fatalf("ReadJournalExport() error = %v", err)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("ReadJournalExport() len = %d, want 2", len(lines))
+	}
+
+	var first map[string]string
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("record 0 is not JSON: %v", err)
+	}
+	if first["MESSAGE"] != "bad\nrequest" || first["PRIORITY"] != "3" || first["_SYSTEMD_UNIT"] != "nginx.service" {
+		t.Errorf("record 0 = %v", first)
+	}
+	if lines[1] != `{"MESSAGE":"second","__CURSOR":"s=2"}` {
+		t.Errorf("record 1 = %s", lines[1])
+	}
+}
+
+func TestIsJournalExport(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{"__CURSOR=s=abc\n", true},
+		{"__REALTIME_TIMESTAMP=1\n", true},
+		{`{"MESSAGE":"x"}`, false},
+		{"plain log line", false},
+	}
+	for _, tt := range tests {
+		if got := IsJournalExport([]byte(tt.in)); got != tt.want {
+			t.Errorf("IsJournalExport(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestStreamLinesReportsErrors(t *testing.T) {
+	var buf bytes.Buffer
+	buf.WriteString("__CURSOR=s=1\nMESSAGE=first\n\n__CURSOR=s=2\nMESSAGE\n")
+	binary.Write(&buf, binary.LittleEndian, uint64(100))
+	buf.WriteString("cut short")
+
+	tests := []struct {
+		name  string
+		input string
+		lines int
+		err   bool
+	}{
+		{"journal export", "__CURSOR=s=1\nMESSAGE=first\n", 1, false},
+		{"truncated journal field", buf.String(), 1, true},
+		{"line too long", "ok\n" + strings.Repeat("x", 2*1024*1024) + "\n", 1, true},
+		{"plain lines", "a\nb\n", 2, false},
+	}
+
+	for _, tt := range tests {
+		ch := make(chan string, 10)
+		errs := make(chan error, 1)
+		streamLines(strings.NewReader(tt.input), ch, errs)
+		n := 0
+		for range ch {
+			n++
+		}
+		var err error
+		select {
+		case err = <-errs:
+		default:
+		}
+		if n != tt.lines || (err != nil) != tt.err {
+			t.Errorf("%s: %d lines, error %v, want %d lines, error %v", tt.name, n, err, tt.lines, tt.err)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package input
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	maxHTTPBody = 32 * 1024 * 1024
+	maxTCPLine  = 1024 * 1024
+)
+
+type ListenConfig struct {
+	UDP  string
+	TCP  string
+	HTTP string
+}
+
+func (c ListenConfig) IsEmpty() bool {
+	return c.UDP == "" && c.TCP == "" && c.HTTP == ""
+}
+
+func (c ListenConfig) String() string {
+	var parts []string
+	if c.UDP != "" {
+		parts = append(parts, "udp"+c.UDP)
+	}
+	if c.TCP != "" {
+		parts = append(parts, "tcp"+c.TCP)
+	}
+	if c.HTTP != "" {
+		parts = append(parts, "http"+c.HTTP)
+	}
+	return strings.Join(parts, " ")
+}
+
+type Listener struct {
+	mu      sync.Mutex
+	closers []io.Closer
+	conns   map[net.Conn]bool
+	closed  bool
+	errs    chan error
+}
+
+func Listen(cfg ListenConfig, ch chan<- string) (*Listener, error) {
+	l := &Listener{conns: make(map[net.Conn]bool), errs: make(chan error, 16)}
+
+	if cfg.UDP != "" {
+		conn, err := net.ListenPacket("udp", LocalAddr(cfg.UDP))
+		if err != nil {
+			l.Close()
+			return nil, err
+		}
+		l.closers = append(l.closers, conn)
+		go l.serveUDP(conn, ch)
+	}
+
+	if cfg.TCP != "" {
+		ln, err := net.Listen("tcp", LocalAddr(cfg.TCP))
+		if err != nil {
+			l.Close()
+			return nil, err
+		}
+		l.closers = append(l.closers, ln)
+		go l.serveTCP(ln, ch)
+	}
+
+	if cfg.HTTP != "" {
+		ln, err := net.Listen("tcp", LocalAddr(cfg.HTTP))
+		if err != nil {
+			l.Close()
+			return nil, err
+		}
+		srv := &http.Server{Handler: httpHandler(ch)}
+		l.closers = append(l.closers, srv)
+		go func() {
+			if err := srv.Serve(ln); err != http.ErrServerClosed {
+				l.report(errors.New("http: " + err.Error()))
+			}
+		}()
+	}
+
+	return l, nil
+}
+
+func LocalAddr(addr string) string {
+	if _, err := strconv.Atoi(addr); err == nil {
+		return net.JoinHostPort("127.0.0.1", addr)
+	}
+	if host, port, err := net.SplitHostPort(addr); err == nil && host == "" {
+		return net.JoinHostPort("127.0.0.1", port)
+	}
+	return addr
+}
+
+func (l *Listener) Errors() <-chan error {
+	return l.errs
+}
+
+func (l *Listener) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.closed = true
+	var first error
+	for _, c := range l.closers {
+		if err := c.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	for conn := range l.conns {
+		conn.Close()
+	}
+	return first
+}
+
+func (l *Listener) report(err error) {
+	l.mu.Lock()
+	closed := l.closed
+	l.mu.Unlock()
+	if closed || errors.Is(err, net.ErrClosed) {
+		return
+	}
+	select {
+	case l.errs <- err:
+	default:
+	}
+}
+
+func (l *Listener) serveUDP(conn net.PacketConn, ch chan<- string) {
+	buf := make([]byte, 65535)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			l.report(errors.New("udp: " + err.Error()))
+			return
+		}
+		sendLines(string(buf[:n]), ch)
+	}
+}
+
+func (l *Listener) serveTCP(ln net.Listener, ch chan<- string) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			l.report(errors.New("tcp: " + err.Error()))
+			return
+		}
+		l.mu.Lock()
+		if l.closed {
+			l.mu.Unlock()
+			conn.Close()
+			return
+		}
+		l.conns[conn] = true
+		l.mu.Unlock()
+
+		go func(c net.Conn) {
+			defer func() {
+				l.mu.Lock()
+				delete(l.conns, c)
+				l.mu.Unlock()
+				c.Close()
+			}()
+			from := c.RemoteAddr().String()
+			err := readLines(c, maxTCPLine, func(line string) { ch <- line }, func() {
+				l.report(errors.New("tcp " + from + ": line over " + strconv.Itoa(maxTCPLine/1024/1024) + "MB truncated"))
+			})
+			if err != nil {
+				l.report(errors.New("tcp " + from + ": " + err.Error()))
+			}
+		}(conn)
+	}
+}
+
+func readLines(r io.Reader, max int, emit func(string), tooLong func()) error {
+	reader := bufio.NewReader(r)
+	var line []byte
+	truncated := false
+	for {
+		chunk, err := reader.ReadSlice('\n')
+		if !truncated {
+			if room := max - len(line); len(chunk) > room && !(len(chunk) == room+1 && chunk[room] == '\n') {
+				line = append(line, chunk[:room]...)
+				truncated = true
+				tooLong()
+			} else {
+				line = append(line, chunk...)
+			}
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if text := strings.TrimRight(string(line), "\r\n"); text != "" {
+			emit(text)
+		}
+		line, truncated = line[:0], false
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func httpHandler(ch chan<- string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if strings.Contains(r.Header.Get("Content-Type"), "protobuf") {
+			http.Error(w, "only OTLP/JSON is supported", http.StatusUnsupportedMediaType)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxHTTPBody))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		trimmed := bytes.TrimSpace(body)
+		if len(trimmed) > 0 && trimmed[0] == '{' && json.Valid(trimmed) {
+			var compact bytes.Buffer
+			if json.Compact(&compact, trimmed) == nil {
+				ch <- compact.String()
+			}
+		} else {
+			sendLines(string(body), ch)
+		}
+
+		if strings.HasPrefix(r.URL.Path, "/v1/") {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte("{}"))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func sendLines(s string, ch chan<- string) {
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimRight(line, "\r"); line != "" {
+			ch <- line
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package input
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLocalAddr(t *testing.T) {
+	tests := []struct {
+		addr, want string
+	}{
+		{":5514", "127.0.0.1:5514"},
+		{"5514", "127.0.0.1:5514"},
+		{"0.0.0.0:5514", "0.0.0.0:5514"},
+		{"localhost:4318", "localhost:4318"},
+		{"[::]:4318", "[::]:4318"},
+	}
+
+	for _, tt := range tests {
+		if got := LocalAddr(tt.addr); got != tt.want {
+			t.Errorf("LocalAddr(%q) = %q, want %q", tt.addr, got, tt.want)
+		}
+	}
+}
+
+func TestReadLines(t *testing.T) {
+	long := strings.Repeat("x", 40)
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		tooLong int
+	}{
+		{"lines", "a\r\nb\n\nc", []string{"a", "b", "c"}, 0},
+		{"exactly max", strings.Repeat("y", 16) + "\nz\n", []string{strings.Repeat("y", 16), "z"}, 0},
+		{"over max keeps the connection", long + "\nnext\n", []string{long[:16], "next"}, 1},
+		{"over max at EOF", "ok\n" + long, []string{"ok", long[:16]}, 1},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		tooLong := 0
+		err := readLines(strings.NewReader(tt.input), 16, func(line string) { got = append(got, line) }, func() { tooLong++ })
+		if err != nil {
+			t.Errorf("%s: error = %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) || tooLong != tt.tooLong {
+			t.Errorf("%s: lines %q (%d too long), want %q (%d)", tt.name, got, tooLong, tt.want, tt.tooLong)
+		}
+	}
+}
+
+func TestHTTPHandler(t *testing.T) {
+	ch := make(chan string, 10)
+	srv := httptest.NewServer(httpHandler(ch))
+	defer srv.Close()
+
+	resp, err := http.Post(srv.URL+"/logs", "application/x-ndjson", strings.NewReader("{\"msg\":\"a\"}\n{\"msg\":\"b\"}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("status = %d, want 204", resp.StatusCode)
+	}
+	if got := []string{<-ch, <-ch}; !reflect.DeepEqual(got, []string{`{"msg":"a"}`, `{"msg":"b"}`}) {
+		t.Errorf("lines = %q", got)
+	}
+
+	resp, err = http.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("GET status = %d, want 405", resp.StatusCode)
+	}
+}
+
+func TestListenClose(t *testing.T) {
+	ch := make(chan string, 10)
+	l, err := Listen(ListenConfig{TCP: "127.0.0.1:0", HTTP: "127.0.0.1:0"}, ch)
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	addr := l.closers[0].(net.Listener).Addr().String()
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Write([]byte("hello\n"))
+	select {
+	case line := <-ch:
+		if line != "hello" {
+			t.Errorf("line = %q", line)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no line received")
+	}
+
+	if err := l.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	if _, err := net.Dial("tcp", addr); err == nil {
+		t.Error("listener still accepting after Close")
+	}
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	if _, err := conn.Read(make([]byte, 1)); err == nil || os.IsTimeout(err) {
+		t.Errorf("open connection not closed: %v", err)
+	}
+	select {
+	case err := <-l.Errors():
+		t.Errorf("error reported after Close: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
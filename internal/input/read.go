@@ -2,6 +2,8 @@ package input
 
 import (
 	"bufio"
+	"bytes"
+	"io"
 	"os"
 	"strings"
 
@@ -13,6 +15,9 @@ func ReadFile(path string) ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
+	if IsJournalExport(content) {
+		return ReadJournalExport(bytes.NewReader(content))
+	}
 	return strings.Split(string(content), "\n"), nil
 }
 
@@ -46,13 +51,27 @@ func WriteClipboard(content string) error {
 	return clipboard.WriteAll(content)
 }
 
-func StreamStdin(ch chan<- string) {
-	scanner := bufio.NewScanner(os.Stdin)
+func StreamStdin(ch chan<- string, errs chan<- error) {
+	streamLines(os.Stdin, ch, errs)
+}
+
+func streamLines(r io.Reader, ch chan<- string, errs chan<- error) {
+	defer close(ch)
+	reader := bufio.NewReader(r)
+	if peekJournalExport(reader) {
+		if err := DecodeJournalExport(reader, func(line string) { ch <- line }); err != nil {
+			errs <- err
+		}
+		return
+	}
+	scanner := bufio.NewScanner(reader)
 	const maxBuf = 1024 * 1024
 	buf := make([]byte, maxBuf)
 	scanner.Buffer(buf, maxBuf)
 	for scanner.Scan() {
 		ch <- scanner.Text()
 	}
-	close(ch)
+	if err := scanner.Err(); err != nil {
+		errs <- err
+	}
 }
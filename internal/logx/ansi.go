@@ -0,0 +1,68 @@
+package logx
+
+import "strings"
+
+func HasANSI(s string) bool {
+	return strings.IndexByte(s, 0x1b) >= 0
+}
+
+// StripANSI removes CSI (colors, cursor movement), OSC (titles, hyperlinks)
+// and two-byte escape sequences, leaving only printable text.
+func StripANSI(s string) string {
+	if !HasANSI(s) {
+		return s
+	}
+	return scanANSI(s, false)
+}
+
+// KeepSGR removes every escape sequence except SGR (ESC[…m) and drops other
+// control characters, so colored lines can be drawn without moving the cursor.
+func KeepSGR(s string) string {
+	return scanANSI(s, true)
+}
+
+func scanANSI(s string, keepSGR bool) string {
+	var b strings.Builder
+	b.Grow(len(s))
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != 0x1b {
+			if !keepSGR || c >= 0x20 || c == '\t' {
+				b.WriteByte(c)
+			}
+			continue
+		}
+		if i+1 >= len(s) {
+			break
+		}
+		switch s[i+1] {
+		case '[':
+			j := i + 2
+			for j < len(s) && (s[j] < 0x40 || s[j] > 0x7e) {
+				j++
+			}
+			if keepSGR && j < len(s) && s[j] == 'm' {
+				b.WriteString(s[i : j+1])
+			}
+			i = j
+		case ']', 'P', '_', '^':
+			j := i + 2
+			for j < len(s) {
+				if s[j] == 0x07 {
+					break
+				}
+				if s[j] == 0x1b && j+1 < len(s) && s[j+1] == '\\' {
+					j++
+					break
+				}
+				j++
+			}
+			i = j
+		default:
+			i++
+		}
+	}
+
+	return b.String()
+}
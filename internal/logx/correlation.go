@@ -0,0 +1,94 @@
+package logx
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var correlationFields = []string{
+	"trace_id", "traceId", "traceID", "trace.id",
+	"request_id", "requestId", "requestID", "req_id", "http.request_id",
+	"correlation_id", "correlationId", "correlationID", "x_request_id",
+}
+
+var serviceFields = []string{"service", "service.name", "resource.service.name", "service_name", "serviceName", "app", "component", "_SYSTEMD_UNIT", "SYSLOG_IDENTIFIER"}
+
+var correlationTextPattern = regexp.MustCompile(`(?i)\b(trace_?id|request_?id|req_id|correlation_?id)[=:]\s*"?([A-Za-z0-9][A-Za-z0-9._:-]*)`)
+
+type CorrelationID struct {
+	Key   string
+	Value string
+}
+
+func CorrelationIDs(e Entry) []CorrelationID {
+	var ids []CorrelationID
+	seen := make(map[string]bool)
+	add := func(key, value string) {
+		if value == "" || seen[value] {
+			return
+		}
+		seen[value] = true
+		ids = append(ids, CorrelationID{Key: key, Value: value})
+	}
+
+	for _, key := range correlationFields {
+		if val, ok := LookupField(e.Fields, key); ok {
+			add(key, fieldString(val))
+		}
+	}
+	for _, m := range correlationTextPattern.FindAllStringSubmatch(e.Raw, -1) {
+		add(m[1], m[2])
+	}
+	return ids
+}
+
+func HasCorrelationID(e Entry, value string) bool {
+	for _, id := range CorrelationIDs(e) {
+		if id.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+func ServiceName(e Entry) string {
+	for _, key := range serviceFields {
+		if val, ok := LookupField(e.Fields, key); ok {
+			if s := fieldString(val); s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+func LookupField(fields map[string]any, path string) (any, bool) {
+	if fields == nil {
+		return nil, false
+	}
+	if val, ok := fields[path]; ok {
+		return val, true
+	}
+	head, rest, found := strings.Cut(path, ".")
+	if !found {
+		return nil, false
+	}
+	nested, ok := fields[head].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	return LookupField(nested, rest)
+}
+
+func fieldString(val any) string {
+	switch v := val.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
@@ -0,0 +1,50 @@
+package logx
+
+import "testing"
+
+func TestCorrelationIDs(t *testing.T) {
+	tests := []struct {
+		line    string
+		want    []CorrelationID
+		service string
+	}{
+		{
+			line:    `{"msg":"ok","trace_id":"abc","request_id":"r-1","service":"api"}`,
+			want:    []CorrelationID{{"trace_id", "abc"}, {"request_id", "r-1"}},
+			service: "api",
+		},
+		{
+			line:    `{"msg":"ok","trace":{"id":"t-9"},"service":{"name":"billing"}}`,
+			want:    []CorrelationID{{"trace.id", "t-9"}},
+			service: "billing",
+		},
+		{
+			line: `2024-05-01 12:00:00 ERROR db timeout request_id=req-42 user=7`,
+			want: []CorrelationID{{"request_id", "req-42"}},
+		},
+		{
+			line: `{"msg":"same","traceId":"abc","correlation_id":"abc"}`,
+			want: []CorrelationID{{"traceId", "abc"}},
+		},
+		{
+			line: `plain line without ids`,
+		},
+	}
+
+	for _, tt := range tests {
+		e := ParseLine(tt.line, 0)
+		got := CorrelationIDs(e)
+		if len(got) != len(tt.want) {
+			t.Errorf("CorrelationIDs(%q) = %v, want %v", tt.line, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("CorrelationIDs(%q)[%d] = %v, want %v", tt.line, i, got[i], tt.want[i])
+			}
+		}
+		if s := ServiceName(e); s != tt.service {
+			t.Errorf("ServiceName(%q) = %q, want %q", tt.line, s, tt.service)
+		}
+	}
+}
@@ -7,10 +7,16 @@ const (
 	LevelTrace
 	LevelDebug
 	LevelInfo
+	LevelNotice
 	LevelWarn
 	LevelError
+	LevelCritical
+	LevelAlert
+	LevelFatal
 )
 
+var Levels = []Level{LevelTrace, LevelDebug, LevelInfo, LevelNotice, LevelWarn, LevelError, LevelCritical, LevelAlert, LevelFatal}
+
 func (l Level) String() string {
 	switch l {
 	case LevelTrace:
@@ -19,18 +25,31 @@ func (l Level) String() string {
 		return "DEBUG"
 	case LevelInfo:
 		return "INFO"
+	case LevelNotice:
+		return "NOTICE"
 	case LevelWarn:
 		return "WARN"
 	case LevelError:
 		return "ERROR"
+	case LevelCritical:
+		return "CRIT"
+	case LevelAlert:
+		return "ALERT"
+	case LevelFatal:
+		return "FATAL"
 	default:
 		return "UNKNOWN"
 	}
 }
 
+func (l Level) IsError() bool {
+	return l >= LevelError
+}
+
 type Entry struct {
 	Index     int
 	Raw       string
+	ANSI      string
 	Message   string
 	Timestamp string
 	Level     Level
@@ -38,4 +57,5 @@ type Entry struct {
 	IsJSON    bool
 	IsStack   bool
 	Deleted   bool
+	Stream    string
 }
@@ -0,0 +1,61 @@
+package logx
+
+import "encoding/json"
+
+type FieldMapping struct {
+	Message   []string
+	Level     []string
+	Timestamp []string
+}
+
+var defaultFieldMapping = FieldMapping{
+	Message:   []string{"msg", "message", "log", "error", "MESSAGE"},
+	Level:     []string{"level", "severity", "lvl", "log_level", "severity_text", "severityText", "severity_number", "severityNumber", "priority", "PRIORITY"},
+	Timestamp: []string{"timestamp", "time", "ts", "@timestamp", "datetime", "date"},
+}
+
+var activeFieldMapping = defaultFieldMapping
+
+func SetFieldMapping(m FieldMapping) {
+	activeFieldMapping = FieldMapping{
+		Message:   mergeFields(m.Message, defaultFieldMapping.Message),
+		Level:     mergeFields(m.Level, defaultFieldMapping.Level),
+		Timestamp: mergeFields(m.Timestamp, defaultFieldMapping.Timestamp),
+	}
+}
+
+func ResetFieldMapping() {
+	activeFieldMapping = defaultFieldMapping
+}
+
+func (m FieldMapping) IsEmpty() bool {
+	return len(m.Message) == 0 && len(m.Level) == 0 && len(m.Timestamp) == 0
+}
+
+func mergeFields(custom, defaults []string) []string {
+	merged := make([]string, 0, len(custom)+len(defaults))
+	seen := make(map[string]bool, len(custom)+len(defaults))
+	for _, list := range [][]string{custom, defaults} {
+		for _, key := range list {
+			if key != "" && !seen[key] {
+				seen[key] = true
+				merged = append(merged, key)
+			}
+		}
+	}
+	return merged
+}
+
+func FieldValue(val any) string {
+	switch v := val.(type) {
+	case nil:
+		return "null"
+	case string, float64, bool:
+		return fieldString(v)
+	}
+	data, err := json.Marshal(val)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -1,13 +1,28 @@
 package logx
 
-import "strings"
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var fieldTermPattern = regexp.MustCompile(`^([\w@][\w.@-]*)=(.*)$`)
+
+var fieldPrefixPattern = regexp.MustCompile(`^!?[\w@][\w.@-]*=$`)
+
+const MessageField = "@msg"
 
 type Filter struct {
-	terms []filterTerm
+	terms  []filterTerm
+	before int
+	after  int
 }
 
 type filterTerm struct {
 	text   string
+	field  string
+	value  string
 	negate bool
 }
 
@@ -17,13 +32,23 @@ func NewFilter(query string) *Filter {
 		return &Filter{}
 	}
 
-	parts := strings.Fields(query)
+	parts := splitQuery(query)
 	terms := make([]filterTerm, 0, len(parts))
 
+	f := &Filter{}
 	for _, part := range parts {
 		if part == "" {
 			continue
 		}
+		if strings.HasPrefix(part, `\`) {
+			if text := strings.ToLower(part[1:]); text != "" {
+				terms = append(terms, filterTerm{text: text})
+			}
+			continue
+		}
+		if f.parseContext(part) {
+			continue
+		}
 		term := filterTerm{}
 		if strings.HasPrefix(part, "!") {
 			term.negate = true
@@ -31,12 +56,83 @@ func NewFilter(query string) *Filter {
 		} else {
 			term.text = strings.ToLower(part)
 		}
+		if m := fieldTermPattern.FindStringSubmatch(strings.TrimPrefix(part, "!")); m != nil {
+			term.field, term.value = m[1], m[2]
+		}
 		if term.text != "" {
 			terms = append(terms, term)
 		}
 	}
 
-	return &Filter{terms: terms}
+	f.terms = terms
+	return f
+}
+
+func splitQuery(query string) []string {
+	var parts []string
+	var b strings.Builder
+	inQuote, escaped := false, false
+	flush := func() {
+		if b.Len() > 0 {
+			parts = append(parts, b.String())
+		}
+		b.Reset()
+	}
+	for _, r := range query {
+		switch {
+		case escaped:
+			b.WriteRune(r)
+			escaped = false
+		case inQuote && r == '\\':
+			escaped = true
+		case inQuote && r == '"':
+			inQuote = false
+		case r == '"' && fieldPrefixPattern.MatchString(b.String()):
+			inQuote = true
+		case !inQuote && unicode.IsSpace(r):
+			flush()
+		default:
+			b.WriteRune(r)
+		}
+	}
+	flush()
+	return parts
+}
+
+func FieldTerm(key, value string, negate bool) string {
+	if value == "" || strings.ContainsAny(value, " \t\"\\") {
+		value = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+	}
+	term := key + "=" + value
+	if negate {
+		term = "!" + term
+	}
+	return term
+}
+
+func (f *Filter) parseContext(part string) bool {
+	if len(part) < 3 || part[0] != '-' {
+		return false
+	}
+	n, err := strconv.Atoi(part[2:])
+	if err != nil || n < 0 {
+		return false
+	}
+	switch part[1] {
+	case 'A':
+		f.after = n
+	case 'B':
+		f.before = n
+	case 'C':
+		f.before, f.after = n, n
+	default:
+		return false
+	}
+	return true
+}
+
+func (f *Filter) Context() (before, after int) {
+	return f.before, f.after
 }
 
 func (f *Filter) IsEmpty() bool {
@@ -44,45 +140,128 @@ func (f *Filter) IsEmpty() bool {
 }
 
 func (f *Filter) Match(text string) bool {
+	return f.MatchEntry(&Entry{Raw: text})
+}
+
+func (f *Filter) MatchEntry(entry *Entry) bool {
 	if f.IsEmpty() {
 		return true
 	}
 
-	lower := strings.ToLower(text)
+	lower := strings.ToLower(entry.Raw)
 	for _, term := range f.terms {
 		contains := strings.Contains(lower, term.text)
-		if term.negate {
-			if contains {
-				return false
-			}
-		} else {
-			if !contains {
-				return false
+		if term.field == MessageField {
+			contains = entry.Message == term.value
+		} else if term.field != "" {
+			if val, ok := LookupField(entry.Fields, term.field); ok {
+				contains = strings.EqualFold(FieldValue(val), term.value)
 			}
 		}
+		if contains == term.negate {
+			return false
+		}
 	}
 	return true
 }
 
+type LevelRange struct {
+	Min                 Level
+	Max                 Level
+	IncludeContinuation bool
+}
+
+func (r LevelRange) Contains(level Level) bool {
+	return level >= r.Min && level <= r.Max
+}
+
 func Apply(entries []Entry, query string) []int {
 	return ApplyWithLevel(entries, query, nil)
 }
 
-func ApplyWithLevel(entries []Entry, query string, levelFilter *Level) []int {
+func ApplyWithLevel(entries []Entry, query string, levels *LevelRange) []int {
+	return ApplyFrom(entries, 0, query, levels)
+}
+
+func ApplyFrom(entries []Entry, start int, query string, levels *LevelRange) []int {
 	filter := NewFilter(query)
-	result := make([]int, 0, len(entries))
+	result := make([]int, 0, len(entries)-start)
+	eventMatches := false
+	if levels != nil {
+		for i := start - 1; i >= 0; i-- {
+			if entries[i].Level != LevelUnknown {
+				eventMatches = levels.Contains(entries[i].Level)
+				break
+			}
+		}
+	}
 
-	for i, entry := range entries {
+	for i := start; i < len(entries); i++ {
+		entry := entries[i]
+		if levels != nil {
+			if entry.Level != LevelUnknown {
+				eventMatches = levels.Contains(entry.Level)
+			}
+		}
 		if entry.Deleted {
 			continue
 		}
-		if levelFilter != nil && entry.Level != *levelFilter {
-			continue
+		if levels != nil {
+			if entry.Level == LevelUnknown {
+				if !levels.IncludeContinuation || !eventMatches {
+					continue
+				}
+			} else if !eventMatches {
+				continue
+			}
 		}
-		if filter.Match(entry.Raw) {
+		if filter.MatchEntry(&entry) {
 			result = append(result, i)
 		}
 	}
 
 	return result
 }
+
+func WithContext(entries []Entry, matches []int, before, after int, extra map[int]int) (rows []int, context map[int]bool) {
+	context = make(map[int]bool)
+	if before <= 0 && after <= 0 && len(extra) == 0 {
+		return matches, context
+	}
+
+	include := make([]bool, len(entries))
+	isMatch := make([]bool, len(entries))
+	for _, idx := range matches {
+		isMatch[idx] = true
+		b, a := before, after
+		if n := extra[idx]; n > 0 {
+			b += n
+			a += n
+		}
+		include[idx] = true
+		for i, n := idx-1, 0; i >= 0 && n < b; i-- {
+			if !entries[i].Deleted {
+				include[i] = true
+				n++
+			}
+		}
+		for i, n := idx+1, 0; i < len(entries) && n < a; i++ {
+			if !entries[i].Deleted {
+				include[i] = true
+				n++
+			}
+		}
+	}
+
+	rows = make([]int, 0, len(matches))
+	for i, ok := range include {
+		if !ok {
+			continue
+		}
+		rows = append(rows, i)
+		if !isMatch[i] {
+			context[i] = true
+		}
+	}
+	return rows, context
+}
@@ -0,0 +1,149 @@
+package logx
+
+import "testing"
+
+func TestApplyWithLevelRange(t *testing.T) {
+	entries := ParseLines([]string{
+		"[INFO] starting",
+		"DEBUG config loaded",
+		"WARN slow query",
+		"ERROR payment failed",
+		"\tat com.acme.Pay.charge(Pay.java:42)",
+		"[INFO] retrying",
+		"\tat com.acme.Retry.run(Retry.java:7)",
+		"FATAL out of memory",
+	})
+
+	tests := []struct {
+		name   string
+		levels *LevelRange
+		want   []int
+	}{
+		{"no level filter", nil, []int{0, 1, 2, 3, 4, 5, 6, 7}},
+		{"warn and above", &LevelRange{Min: LevelWarn, Max: LevelFatal}, []int{2, 3, 7}},
+		{"exact error", &LevelRange{Min: LevelError, Max: LevelError}, []int{3}},
+		{"debug..info", &LevelRange{Min: LevelDebug, Max: LevelInfo}, []int{0, 1, 5}},
+		{"error and above with continuation", &LevelRange{Min: LevelError, Max: LevelFatal, IncludeContinuation: true}, []int{3, 4, 7}},
+	}
+
+	for _, tt := range tests {
+		got := ApplyWithLevel(entries, "", tt.levels)
+		if !equalInts(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+		for split := 0; split <= len(entries); split++ {
+			head := ApplyWithLevel(entries[:split], "", tt.levels)
+			tail := ApplyFrom(entries, split, "", tt.levels)
+			if got := append(head, tail...); !equalInts(got, tt.want) {
+				t.Errorf("%s split at %d: got %v, want %v", tt.name, split, got, tt.want)
+			}
+		}
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestFilterContext(t *testing.T) {
+	lines := []string{"a", "b", "payment failed", "c", "d", "e", "f", "payment failed", "g"}
+	entries := ParseLines(lines)
+
+	f := NewFilter("payment -B2 -A1")
+	if b, a := f.Context(); b != 2 || a != 1 {
+		t.Fatalf("Context() = %d, %d, want 2, 1", b, a)
+	}
+	if f.Match("-B2") {
+		t.Errorf("context flags must not be treated as text terms")
+	}
+
+	matches := Apply(entries, "payment -B2 -A1")
+	rows, context := WithContext(entries, matches, 2, 1, nil)
+	want := []int{0, 1, 2, 3, 5, 6, 7, 8}
+	if !equalInts(rows, want) {
+		t.Errorf("rows = %v, want %v", rows, want)
+	}
+	if context[2] || context[7] || !context[0] || !context[8] {
+		t.Errorf("context flags wrong: %v", context)
+	}
+
+	rows, _ = WithContext(entries, []int{7}, 0, 0, map[int]int{7: 1})
+	if !equalInts(rows, []int{6, 7, 8}) {
+		t.Errorf("expanded rows = %v, want [6 7 8]", rows)
+	}
+}
+
+func TestFilterFieldTerms(t *testing.T) {
+	entries := ParseLines([]string{
+		`{"level":"error","msg":"charge failed","service":"payments","http":{"status":500}}`,
+		`{"level":"info","msg":"charge ok","service":"payments","http":{"status":200}}`,
+		`{"level":"info","msg":"login","service":"auth","user":"Jane Doe"}`,
+		`level=warn service=payments msg=retry`,
+		`"error" returned by "payments"`,
+	})
+
+	tests := []struct {
+		query string
+		want  []int
+	}{
+		{"service=payments", []int{0, 1, 3}},
+		{"!service=payments", []int{2, 4}},
+		{"http.status=500", []int{0}},
+		{"!http.status=500 service=payments", []int{1, 3}},
+		{"SERVICE=AUTH", []int{}},
+		{"service=AUTH", []int{2}},
+		{`user="jane doe"`, []int{2}},
+		{`"charge ok"`, []int{1}},
+		{"service=pay", []int{3}},
+		{`@msg="charge ok"`, []int{1}},
+		{`@msg="charge"`, []int{}},
+		{`!@msg=login`, []int{0, 1, 3, 4}},
+		{`"error"`, []int{0, 4}},
+		{`"returned"`, []int{}},
+		{`by "payments"`, []int{4}},
+		{`\service=payments`, []int{3}},
+		{`\!http`, []int{}},
+		{`\-B5`, []int{}},
+	}
+
+	for _, tt := range tests {
+		got := Apply(entries, tt.query)
+		if !equalInts(got, tt.want) {
+			t.Errorf("Apply(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestFieldTerm(t *testing.T) {
+	tests := []struct {
+		key, value string
+		negate     bool
+		want       string
+	}{
+		{"service", "payments", false, "service=payments"},
+		{"http.status", "500", true, "!http.status=500"},
+		{"user", "Jane Doe", false, `user="Jane Doe"`},
+		{"msg", `say "hi"`, false, `msg="say \"hi\""`},
+		{"empty", "", false, `empty=""`},
+		{MessageField, "user=bob failed", false, `@msg="user=bob failed"`},
+	}
+
+	for _, tt := range tests {
+		got := FieldTerm(tt.key, tt.value, tt.negate)
+		if got != tt.want {
+			t.Errorf("FieldTerm(%q, %q, %v) = %q, want %q", tt.key, tt.value, tt.negate, got, tt.want)
+		}
+		terms := NewFilter(got).terms
+		if len(terms) != 1 || terms[0].field != tt.key || terms[0].value != tt.value || terms[0].negate != tt.negate {
+			t.Errorf("NewFilter(%q) terms = %+v", got, terms)
+		}
+	}
+}
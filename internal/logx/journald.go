@@ -0,0 +1,53 @@
+package logx
+
+import (
+	"strconv"
+	"time"
+)
+
+var journalTimestampFields = []string{"__REALTIME_TIMESTAMP", "_SOURCE_REALTIME_TIMESTAMP"}
+
+var journalUnitFields = []string{"_SYSTEMD_UNIT", "_SYSTEMD_USER_UNIT"}
+
+func addJournalUnit(fields map[string]any) {
+	if _, ok := fields["unit"]; ok {
+		return
+	}
+	for _, key := range journalUnitFields {
+		if val, ok := fields[key].(string); ok && val != "" {
+			fields["unit"] = val
+			return
+		}
+	}
+}
+
+func journalTimestamp(fields map[string]any) string {
+	for _, key := range journalTimestampFields {
+		val, ok := fields[key].(string)
+		if !ok {
+			continue
+		}
+		usec, err := strconv.ParseInt(val, 10, 64)
+		if err != nil || usec <= 0 {
+			continue
+		}
+		return time.UnixMicro(usec).UTC().Format(time.RFC3339Nano)
+	}
+	return ""
+}
+
+func journalBytes(val any) string {
+	arr, ok := val.([]any)
+	if !ok || len(arr) == 0 {
+		return ""
+	}
+	buf := make([]byte, 0, len(arr))
+	for _, v := range arr {
+		n, ok := v.(float64)
+		if !ok || n < 0 || n > 255 {
+			return ""
+		}
+		buf = append(buf, byte(n))
+	}
+	return StripANSI(string(buf))
+}
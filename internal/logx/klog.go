@@ -0,0 +1,58 @@
+package logx
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var klogPattern = regexp.MustCompile(`^([IWEF])(\d{2})(\d{2}) (\d{2}:\d{2}:\d{2}(?:\.\d+)?)\s+(\d+) ([^\s:\]]+):(\d+)\] ?(.*)$`)
+
+var timeNow = time.Now
+
+func parseKlog(entry Entry, trimmed string) (Entry, bool) {
+	m := klogPattern.FindStringSubmatch(trimmed)
+	if m == nil {
+		return entry, false
+	}
+
+	month, _ := strconv.Atoi(m[2])
+	day, _ := strconv.Atoi(m[3])
+	if month < 1 || month > 12 || day < 1 || day > 31 {
+		return entry, false
+	}
+
+	entry.Level = klogLevel(m[1])
+	entry.Timestamp = inferYear(month, day) + " " + m[4]
+	entry.Message = m[8]
+	entry.Fields = map[string]any{
+		"caller": m[6] + ":" + m[7],
+		"thread": m[5],
+	}
+	return entry, true
+}
+
+func klogLevel(letter string) Level {
+	switch letter {
+	case "I":
+		return LevelInfo
+	case "W":
+		return LevelWarn
+	case "E":
+		return LevelError
+	default:
+		return LevelFatal
+	}
+}
+
+// inferYear picks the most recent year in which month/day is not in the
+// future, so December logs read in January land in the previous year.
+func inferYear(month, day int) string {
+	now := timeNow()
+	year := now.Year()
+	candidate := time.Date(year, time.Month(month), day, 0, 0, 0, 0, now.Location())
+	if candidate.After(now.Add(24 * time.Hour)) {
+		year--
+	}
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC).Format("2006-01-02")
+}
@@ -0,0 +1,150 @@
+package logx
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var defaultLevelAliases = map[string]Level{
+	"trace":         LevelTrace,
+	"verbose":       LevelTrace,
+	"debug":         LevelDebug,
+	"dbg":           LevelDebug,
+	"info":          LevelInfo,
+	"information":   LevelInfo,
+	"informational": LevelInfo,
+	"notice":        LevelNotice,
+	"warn":          LevelWarn,
+	"warning":       LevelWarn,
+	"error":         LevelError,
+	"err":           LevelError,
+	"critical":      LevelCritical,
+	"crit":          LevelCritical,
+	"alert":         LevelAlert,
+	"fatal":         LevelFatal,
+	"panic":         LevelFatal,
+	"emerg":         LevelFatal,
+	"emergency":     LevelFatal,
+}
+
+var customLevelAliases = map[string]Level{}
+
+var customAliasOrder []string
+
+var otelSeverityFields = map[string]bool{"severity_number": true, "severitynumber": true}
+
+var syslogSeverityFields = map[string]bool{"priority": true, "severity": true, "syslog_severity": true, "syslog.severity": true}
+
+func SetLevelAlias(alias string, level Level) {
+	key := strings.ToLower(strings.TrimSpace(alias))
+	if _, ok := customLevelAliases[key]; !ok {
+		customAliasOrder = append(customAliasOrder, key)
+		sort.Strings(customAliasOrder)
+	}
+	customLevelAliases[key] = level
+}
+
+func ResetLevelAliases() {
+	customLevelAliases = map[string]Level{}
+	customAliasOrder = nil
+}
+
+func ParseLevel(name string) (Level, bool) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if level, ok := customLevelAliases[key]; ok {
+		return level, true
+	}
+	if level, ok := defaultLevelAliases[key]; ok {
+		return level, true
+	}
+	return LevelUnknown, false
+}
+
+func normalizeLevel(key string, val any) Level {
+	key = strings.ToLower(key)
+
+	var num float64
+	switch v := val.(type) {
+	case float64:
+		num = v
+	case string:
+		if level, ok := ParseLevel(v); ok {
+			return level
+		}
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return LevelUnknown
+		}
+		num = n
+	default:
+		return LevelUnknown
+	}
+
+	switch {
+	case otelSeverityFields[key]:
+		return otelSeverity(int(num))
+	case syslogSeverityFields[key]:
+		return syslogSeverity(int(num))
+	default:
+		return pinoLevel(int(num))
+	}
+}
+
+func pinoLevel(n int) Level {
+	switch n {
+	case 10:
+		return LevelTrace
+	case 20:
+		return LevelDebug
+	case 30:
+		return LevelInfo
+	case 40:
+		return LevelWarn
+	case 50:
+		return LevelError
+	case 60:
+		return LevelFatal
+	}
+	return LevelUnknown
+}
+
+func syslogSeverity(n int) Level {
+	switch n {
+	case 0:
+		return LevelFatal
+	case 1:
+		return LevelAlert
+	case 2:
+		return LevelCritical
+	case 3:
+		return LevelError
+	case 4:
+		return LevelWarn
+	case 5:
+		return LevelNotice
+	case 6:
+		return LevelInfo
+	case 7:
+		return LevelDebug
+	}
+	return LevelUnknown
+}
+
+func otelSeverity(n int) Level {
+	switch {
+	case n >= 1 && n <= 4:
+		return LevelTrace
+	case n >= 5 && n <= 8:
+		return LevelDebug
+	case n >= 9 && n <= 12:
+		return LevelInfo
+	case n >= 13 && n <= 16:
+		return LevelWarn
+	case n >= 17 && n <= 20:
+		return LevelError
+	case n >= 21 && n <= 24:
+		return LevelFatal
+	}
+	return LevelUnknown
+}
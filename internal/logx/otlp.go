@@ -0,0 +1,313 @@
+package logx
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type otlpExport struct {
+	ResourceLogs  []otlpResourceLogs  `json:"resourceLogs"`
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResource    `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpScopeLogs struct {
+	Scope      otlpScope       `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano         any            `json:"timeUnixNano"`
+	ObservedTimeUnixNano any            `json:"observedTimeUnixNano"`
+	SeverityNumber       int            `json:"severityNumber"`
+	SeverityText         string         `json:"severityText"`
+	Body                 *otlpAnyValue  `json:"body"`
+	Attributes           []otlpKeyValue `json:"attributes"`
+	TraceID              string         `json:"traceId"`
+	SpanID               string         `json:"spanId"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId"`
+	Name              string         `json:"name"`
+	Kind              any            `json:"kind"`
+	StartTimeUnixNano any            `json:"startTimeUnixNano"`
+	EndTimeUnixNano   any            `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes"`
+	Status            struct {
+		Code    any    `json:"code"`
+		Message string `json:"message"`
+	} `json:"status"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string `json:"stringValue"`
+	BoolValue   *bool   `json:"boolValue"`
+	IntValue    any     `json:"intValue"`
+	DoubleValue any     `json:"doubleValue"`
+	BytesValue  *string `json:"bytesValue"`
+	ArrayValue  *struct {
+		Values []otlpAnyValue `json:"values"`
+	} `json:"arrayValue"`
+	KvlistValue *struct {
+		Values []otlpKeyValue `json:"values"`
+	} `json:"kvlistValue"`
+}
+
+var spanKinds = []string{"UNSPECIFIED", "INTERNAL", "SERVER", "CLIENT", "PRODUCER", "CONSUMER"}
+
+func IsOTLP(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, "{") &&
+		(strings.Contains(trimmed, `"resourceLogs"`) || strings.Contains(trimmed, `"resourceSpans"`))
+}
+
+func ParseOTLP(line string, index int) ([]Entry, bool) {
+	var export otlpExport
+	if err := json.Unmarshal([]byte(strings.TrimSpace(line)), &export); err != nil {
+		return nil, false
+	}
+	if len(export.ResourceLogs) == 0 && len(export.ResourceSpans) == 0 {
+		return nil, false
+	}
+
+	var entries []Entry
+	for _, rl := range export.ResourceLogs {
+		resource := otlpResourceFields(rl.Resource)
+		for _, sl := range rl.ScopeLogs {
+			for _, rec := range sl.LogRecords {
+				entries = append(entries, otlpLogEntry(rec, sl.Scope, resource, index))
+			}
+		}
+	}
+	for _, rs := range export.ResourceSpans {
+		resource := otlpResourceFields(rs.Resource)
+		for _, ss := range rs.ScopeSpans {
+			for _, span := range ss.Spans {
+				entries = append(entries, otlpSpanEntry(span, ss.Scope, resource, index))
+			}
+		}
+	}
+	return entries, true
+}
+
+func otlpLogEntry(rec otlpLogRecord, scope otlpScope, resource map[string]any, index int) Entry {
+	fields := make(map[string]any, len(rec.Attributes)+len(resource)+6)
+	for k, v := range resource {
+		fields[k] = v
+	}
+	for _, kv := range rec.Attributes {
+		fields[kv.Key] = kv.Value.value()
+	}
+
+	var message string
+	if rec.Body != nil {
+		body := rec.Body.value()
+		if s, ok := body.(string); ok {
+			message = s
+		} else if b, err := json.Marshal(body); err == nil {
+			message = string(b)
+		}
+		fields["message"] = body
+	}
+
+	level := otelSeverity(rec.SeverityNumber)
+	if level == LevelUnknown && rec.SeverityText != "" {
+		level = normalizeLevel("severity_text", rec.SeverityText)
+	}
+	if rec.SeverityNumber > 0 {
+		fields["severity_number"] = float64(rec.SeverityNumber)
+	}
+	if rec.SeverityText != "" {
+		fields["level"] = rec.SeverityText
+	}
+
+	timestamp := otlpTime(rec.TimeUnixNano)
+	if timestamp == "" {
+		timestamp = otlpTime(rec.ObservedTimeUnixNano)
+	}
+	if timestamp != "" {
+		fields["timestamp"] = timestamp
+	}
+	otlpSetIDs(fields, rec.TraceID, rec.SpanID, "")
+	if scope.Name != "" {
+		fields["scope"] = scope.Name
+	}
+
+	if level == LevelUnknown {
+		level = detectLevelText(message)
+	}
+	return otlpEntry(fields, message, level, timestamp, index)
+}
+
+func otlpSpanEntry(span otlpSpan, scope otlpScope, resource map[string]any, index int) Entry {
+	fields := make(map[string]any, len(span.Attributes)+len(resource)+8)
+	for k, v := range resource {
+		fields[k] = v
+	}
+	for _, kv := range span.Attributes {
+		fields[kv.Key] = kv.Value.value()
+	}
+	fields["message"] = span.Name
+	otlpSetIDs(fields, span.TraceID, span.SpanID, span.ParentSpanID)
+	if scope.Name != "" {
+		fields["scope"] = scope.Name
+	}
+	if kind := otlpEnum(span.Kind, "SPAN_KIND_", spanKinds); kind != "" {
+		fields["kind"] = kind
+	}
+
+	start, startOK := otlpNanos(span.StartTimeUnixNano)
+	end, endOK := otlpNanos(span.EndTimeUnixNano)
+	timestamp := otlpTime(span.StartTimeUnixNano)
+	if timestamp != "" {
+		fields["timestamp"] = timestamp
+	}
+	if startOK && endOK && end >= start {
+		fields["duration_ms"] = float64(end-start) / 1e6
+	}
+
+	level := LevelInfo
+	status := otlpEnum(span.Status.Code, "STATUS_CODE_", []string{"UNSET", "OK", "ERROR"})
+	if status != "" {
+		fields["status"] = status
+	}
+	if status == "ERROR" {
+		level = LevelError
+	}
+	if span.Status.Message != "" {
+		fields["status_message"] = span.Status.Message
+	}
+
+	return otlpEntry(fields, span.Name, level, timestamp, index)
+}
+
+func otlpEntry(fields map[string]any, message string, level Level, timestamp string, index int) Entry {
+	raw, _ := json.Marshal(fields)
+	return Entry{
+		Index:     index,
+		Raw:       string(raw),
+		Message:   message,
+		Timestamp: timestamp,
+		Level:     level,
+		Fields:    fields,
+		IsJSON:    true,
+	}
+}
+
+func otlpResourceFields(r otlpResource) map[string]any {
+	fields := make(map[string]any, len(r.Attributes))
+	for _, kv := range r.Attributes {
+		fields["resource."+kv.Key] = kv.Value.value()
+	}
+	return fields
+}
+
+func otlpSetIDs(fields map[string]any, traceID, spanID, parentID string) {
+	if traceID != "" {
+		fields["trace_id"] = traceID
+	}
+	if spanID != "" {
+		fields["span_id"] = spanID
+	}
+	if parentID != "" {
+		fields["parent_span_id"] = parentID
+	}
+}
+
+func otlpNanos(v any) (int64, bool) {
+	switch n := v.(type) {
+	case string:
+		i, err := strconv.ParseInt(n, 10, 64)
+		return i, err == nil && i > 0
+	case float64:
+		return int64(n), n > 0
+	}
+	return 0, false
+}
+
+func otlpTime(v any) string {
+	n, ok := otlpNanos(v)
+	if !ok {
+		return ""
+	}
+	return time.Unix(0, n).UTC().Format(time.RFC3339Nano)
+}
+
+func otlpEnum(v any, prefix string, names []string) string {
+	switch n := v.(type) {
+	case string:
+		return strings.TrimPrefix(n, prefix)
+	case float64:
+		if i := int(n); i > 0 && i < len(names) {
+			return names[i]
+		}
+	}
+	return ""
+}
+
+func (v otlpAnyValue) value() any {
+	switch {
+	case v.StringValue != nil:
+		return *v.StringValue
+	case v.BoolValue != nil:
+		return *v.BoolValue
+	case v.IntValue != nil:
+		if s, ok := v.IntValue.(string); ok {
+			if f, err := strconv.ParseFloat(s, 64); err == nil {
+				return f
+			}
+			return s
+		}
+		return v.IntValue
+	case v.DoubleValue != nil:
+		return v.DoubleValue
+	case v.BytesValue != nil:
+		return *v.BytesValue
+	case v.ArrayValue != nil:
+		values := make([]any, 0, len(v.ArrayValue.Values))
+		for _, item := range v.ArrayValue.Values {
+			values = append(values, item.value())
+		}
+		return values
+	case v.KvlistValue != nil:
+		values := make(map[string]any, len(v.KvlistValue.Values))
+		for _, kv := range v.KvlistValue.Values {
+			values[kv.Key] = kv.Value.value()
+		}
+		return values
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package logx
+
+import "testing"
+
+const otlpLogsLine = `{"resourceLogs":[{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"checkout"}}]},"scopeLogs":[{"scope":{"name":"app"},"logRecords":[` +
+	`{"timeUnixNano":"1714564800000000000","severityNumber":17,"severityText":"ERROR","body":{"stringValue":"payment failed"},"attributes":[{"key":"http.status_code","value":{"intValue":"502"}},{"key":"retry","value":{"boolValue":true}}],"traceId":"5b8efff798038103d269b633813fc60c","spanId":"eee19b7ec3c1b174"},` +
+	`{"observedTimeUnixNano":"1714564801500000000","severityText":"warn","body":{"kvlistValue":{"values":[{"key":"k","value":{"stringValue":"v"}}]}}}` +
+	`]}]}]}`
+
+const otlpSpansLine = `{"resourceSpans":[{"resource":{"attributes":[{"key":"service.name","value":{"stringValue":"api"}}]},"scopeSpans":[{"spans":[` +
+	`{"traceId":"abc","spanId":"s1","name":"GET /pay","kind":2,"startTimeUnixNano":"1714564800000000000","endTimeUnixNano":"1714564800250000000","status":{"code":2,"message":"boom"}}` +
+	`]}]}]}`
+
+func TestParseOTLPLogs(t *testing.T) {
+	entries := ParseLines([]string{otlpLogsLine, `plain INFO line`})
+	if len(entries) != 3 {
+		t.Fatalf("ParseLines(otlp) len = %d, want 3", len(entries))
+	}
+
+	e := entries[0]
+	if e.Message != "payment failed" || e.Level != LevelError || !e.IsJSON {
+		t.Errorf("record 0 = %q %v json=%v, want payment failed ERROR json", e.Message, e.Level, e.IsJSON)
+	}
+	if e.Timestamp != "2024-05-01T12:00:00Z" {
+		t.Errorf("record 0 Timestamp = %q, want 2024-05-01T12:00:00Z", e.Timestamp)
+	}
+	if e.Fields["trace_id"] != "5b8efff798038103d269b633813fc60c" || e.Fields["span_id"] != "eee19b7ec3c1b174" {
+		t.Errorf("record 0 ids = %v/%v", e.Fields["trace_id"], e.Fields["span_id"])
+	}
+	if e.Fields["http.status_code"] != float64(502) || e.Fields["retry"] != true {
+		t.Errorf("record 0 attributes = %v", e.Fields)
+	}
+	if ServiceName(e) != "checkout" {
+		t.Errorf("ServiceName(record 0) = %q, want checkout", ServiceName(e))
+	}
+	if got := Apply([]Entry{e}, "5b8efff7"); len(got) != 1 {
+		t.Errorf("filter by trace id did not match record 0")
+	}
+
+	e = entries[1]
+	if e.Level != LevelWarn || e.Message != `{"k":"v"}` || e.Timestamp != "2024-05-01T12:00:01.5Z" {
+		t.Errorf("record 1 = %q %v %q", e.Message, e.Level, e.Timestamp)
+	}
+}
+
+func TestParseOTLPSpans(t *testing.T) {
+	entries := ParseLines([]string{otlpSpansLine})
+	if len(entries) != 1 {
+		t.Fatalf("ParseLines(spans) len = %d, want 1", len(entries))
+	}
+	e := entries[0]
+	if e.Message != "GET /pay" || e.Level != LevelError {
+		t.Errorf("span = %q %v, want GET /pay ERROR", e.Message, e.Level)
+	}
+	if e.Fields["duration_ms"] != float64(250) || e.Fields["kind"] != "SERVER" || e.Fields["status_message"] != "boom" {
+		t.Errorf("span fields = %v", e.Fields)
+	}
+}
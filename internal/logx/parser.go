@@ -20,11 +20,26 @@ var stackPatterns = []*regexp.Regexp{
 	regexp.MustCompile(`\.go:\d+`),
 	regexp.MustCompile(`^\s*File\s+"[^"]+\.py",\s+line\s+\d+`),
 	regexp.MustCompile(`^\s+at\s+`),
+	regexp.MustCompile(`^\[signal [A-Z]+`),
 }
 
-var messageFields = []string{"msg", "message", "log", "error"}
+var panicPattern = regexp.MustCompile(`^(panic|fatal error): `)
 
-var levelFields = []string{"level", "severity", "lvl", "log_level"}
+var goroutinePattern = regexp.MustCompile(`^goroutine \d+ \[`)
+
+var goFramePattern = regexp.MustCompile(`^(created by )?[\w./\-]+(\.\(\*?\w+\))?\.[\w.]+\(.*\)( in goroutine \d+)?$`)
+
+var goFileLinePattern = regexp.MustCompile(`^\t.+\.go:\d+`)
+
+type Parser struct {
+	index   int
+	inStack bool
+	pending []string
+}
+
+func NewParser() *Parser {
+	return &Parser{}
+}
 
 func ParseLine(raw string, index int) Entry {
 	entry := Entry{
@@ -32,12 +47,21 @@ func ParseLine(raw string, index int) Entry {
 		Raw:   raw,
 	}
 
+	if HasANSI(raw) {
+		if colored := KeepSGR(raw); HasANSI(colored) {
+			entry.ANSI = colored
+		}
+		raw = StripANSI(raw)
+		entry.Raw = raw
+	}
+
 	trimmed := strings.TrimSpace(raw)
 
 	if strings.HasPrefix(trimmed, "{") && strings.HasSuffix(trimmed, "}") {
 		var fields map[string]any
 		if err := json.Unmarshal([]byte(trimmed), &fields); err == nil {
 			entry.IsJSON = true
+			addJournalUnit(fields)
 			entry.Fields = fields
 			entry.Message = extractMessage(fields, trimmed)
 			entry.Level = extractLevelJSON(fields)
@@ -50,7 +74,25 @@ func ParseLine(raw string, index int) Entry {
 		}
 	}
 
+	if patternEntry, ok := parseLinePattern(entry, trimmed); ok {
+		return patternEntry
+	}
+
+	if strings.HasPrefix(trimmed, "<") {
+		if syslogEntry, ok := parseSyslog(entry, trimmed); ok {
+			return syslogEntry
+		}
+	}
+
+	if klogEntry, ok := parseKlog(entry, trimmed); ok {
+		return klogEntry
+	}
+
 	entry.Message = trimmed
+	if panicPattern.MatchString(trimmed) {
+		entry.Level = LevelFatal
+		return entry
+	}
 	entry.Level = detectLevelText(trimmed)
 	entry.Timestamp = extractTimestampText(trimmed)
 	entry.IsStack = isStackTrace(trimmed)
@@ -59,22 +101,89 @@ func ParseLine(raw string, index int) Entry {
 }
 
 func ParseLines(lines []string) []Entry {
+	p := NewParser()
+	return append(p.Parse(lines), p.Flush()...)
+}
+
+func (p *Parser) Parse(lines []string) []Entry {
+	if len(p.pending) > 0 {
+		lines = append(p.pending, lines...)
+		p.pending = nil
+	}
 	entries := make([]Entry, 0, len(lines))
 	for i, line := range lines {
+		index := p.index + i
 		if line == "" {
 			continue
 		}
-		entries = append(entries, ParseLine(line, i))
+		if IsOTLP(line) {
+			if expanded, ok := ParseOTLP(line, index); ok {
+				p.inStack = false
+				entries = append(entries, expanded...)
+				continue
+			}
+		}
+		e := ParseLine(line, index)
+		if i+1 == len(lines) && p.frameCandidate(e) {
+			p.pending = []string{line}
+			p.index += i
+			return entries
+		}
+		frame := goFramePattern.MatchString(e.Message) && i+1 < len(lines) && goFileLinePattern.MatchString(lines[i+1])
+		entries = append(entries, p.track(e, frame))
 	}
+	p.index += len(lines)
 	return entries
 }
 
+func (p *Parser) Pending() bool {
+	return len(p.pending) > 0
+}
+
+func (p *Parser) Flush() []Entry {
+	if len(p.pending) == 0 {
+		return nil
+	}
+	e := ParseLine(p.pending[0], p.index)
+	p.pending = nil
+	p.index++
+	return []Entry{p.track(e, false)}
+}
+
+func (p *Parser) frameCandidate(e Entry) bool {
+	return !p.inStack && !e.IsJSON && e.Timestamp == "" && goFramePattern.MatchString(e.Message)
+}
+
+func (p *Parser) track(e Entry, frame bool) Entry {
+	if e.IsJSON {
+		p.inStack = false
+		return e
+	}
+	if panicPattern.MatchString(e.Message) || goroutinePattern.MatchString(e.Message) {
+		p.inStack = true
+		return e
+	}
+	if frame && e.Timestamp == "" {
+		p.inStack = true
+	}
+	if p.inStack && e.Timestamp == "" && (e.IsStack || strings.HasPrefix(e.Raw, "\t") || goFramePattern.MatchString(e.Message)) {
+		e.IsStack = true
+		e.Level = LevelUnknown
+		return e
+	}
+	p.inStack = false
+	return e
+}
+
 func extractMessage(fields map[string]any, raw string) string {
-	for _, key := range messageFields {
-		if val, ok := fields[key]; ok {
+	for _, key := range activeFieldMapping.Message {
+		if val, ok := LookupField(fields, key); ok {
 			if str, ok := val.(string); ok && str != "" {
 				return str
 			}
+			if str := journalBytes(val); str != "" {
+				return str
+			}
 		}
 	}
 	compact, err := json.Marshal(fields)
@@ -85,62 +194,38 @@ func extractMessage(fields map[string]any, raw string) string {
 }
 
 func extractLevelJSON(fields map[string]any) Level {
-	for _, key := range levelFields {
-		if val, ok := fields[key]; ok {
-			return normalizeLevel(val)
+	for _, key := range activeFieldMapping.Level {
+		if val, ok := LookupField(fields, key); ok {
+			if level := normalizeLevel(key, val); level != LevelUnknown {
+				return level
+			}
 		}
 	}
 	return LevelUnknown
 }
 
-func normalizeLevel(val any) Level {
-	var str string
-	switch v := val.(type) {
-	case string:
-		str = strings.ToLower(v)
-	case float64:
-		switch int(v) {
-		case 10:
-			return LevelTrace
-		case 20:
-			return LevelDebug
-		case 30:
-			return LevelInfo
-		case 40:
-			return LevelWarn
-		case 50, 60:
-			return LevelError
-		}
-		return LevelUnknown
-	default:
-		return LevelUnknown
-	}
-
-	switch str {
-	case "error", "err", "fatal", "panic", "critical", "crit":
-		return LevelError
-	case "warn", "warning":
-		return LevelWarn
-	case "info", "information":
-		return LevelInfo
-	case "debug":
-		return LevelDebug
-	case "trace", "verbose":
-		return LevelTrace
-	default:
-		return LevelUnknown
-	}
-}
-
 func detectLevelText(text string) Level {
 	upper := strings.ToUpper(text)
+	lead := leadingToken(upper)
 
-	if containsAny(upper, "ERROR", "ERR]", "[ERR", "FATAL", "PANIC", "CRITICAL") {
+	if containsAny(upper, "FATAL") || hasLevelTag(upper, lead, "EMERG", "PANIC") {
+		return LevelFatal
+	}
+	if hasLevelTag(upper, lead, "ALERT") {
+		return LevelAlert
+	}
+	if containsAny(upper, "CRITICAL") || hasLevelTag(upper, lead, "CRIT") {
+		return LevelCritical
+	}
+	if containsAny(upper, "ERROR", "ERR]", "[ERR") {
 		return LevelError
 	}
 	if containsAny(upper, "WARN", "WARNING") {
 		return LevelWarn
 	}
+	if hasLevelTag(upper, lead, "NOTICE") {
+		return LevelNotice
+	}
 	if containsAny(upper, "[INFO", "INFO]", " INFO ") {
 		return LevelInfo
 	}
@@ -150,9 +235,34 @@ func detectLevelText(text string) Level {
 	if containsAny(upper, "TRACE", "VERBOSE") {
 		return LevelTrace
 	}
+	for _, alias := range customAliasOrder {
+		if hasLevelTag(upper, lead, strings.ToUpper(alias)) {
+			return customLevelAliases[alias]
+		}
+	}
 	return LevelUnknown
 }
 
+func leadingToken(upper string) string {
+	s := strings.TrimSpace(upper)
+	if ts := extractTimestampText(s); ts != "" {
+		s = strings.TrimSpace(s[len(ts):])
+	}
+	if i := strings.IndexAny(s, " \t"); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimRight(s, ":")
+}
+
+func hasLevelTag(upper, lead string, tags ...string) bool {
+	for _, tag := range tags {
+		if lead == tag || containsAny(upper, "["+tag, tag+"]", "LEVEL="+tag, `LEVEL="`+tag) {
+			return true
+		}
+	}
+	return false
+}
+
 func containsAny(text string, substrs ...string) bool {
 	for _, s := range substrs {
 		if strings.Contains(text, s) {
@@ -163,14 +273,16 @@ func containsAny(text string, substrs ...string) bool {
 }
 
 func extractTimestampJSON(fields map[string]any) string {
-	timestampKeys := []string{"timestamp", "time", "ts", "@timestamp", "datetime", "date"}
-	for _, key := range timestampKeys {
-		if val, ok := fields[key]; ok {
+	for _, key := range activeFieldMapping.Timestamp {
+		if val, ok := LookupField(fields, key); ok {
 			if v, ok := val.(string); ok {
 				return v
 			}
 		}
 	}
+	if ts := journalTimestamp(fields); ts != "" {
+		return ts
+	}
 	return ""
 }
 
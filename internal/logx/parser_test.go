@@ -0,0 +1,397 @@
+package logx
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestParseKlog(t *testing.T) {
+	timeNow = func() time.Time { return time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC) }
+	defer func() { timeNow = time.Now }()
+
+	tests := []struct {
+		raw       string
+		wantLevel Level
+		wantTS    string
+		wantMsg   string
+		wantCall  string
+	}{
+		{"E0501 12:00:00.123456   12345 controller.go:123] sync failed", LevelError, "2024-05-01 12:00:00.123456", "sync failed", "controller.go:123"},
+		{"I0615 08:30:00.000001       1 main.go:42] starting", LevelInfo, "2024-06-15 08:30:00.000001", "starting", "main.go:42"},
+		{"W1231 23:59:59.999999      7 leader.go:9] lease lost", LevelWarn, "2023-12-31 23:59:59.999999", "lease lost", "leader.go:9"},
+		{"F0101 00:00:00.000000      7 server.go:1] boom", LevelFatal, "2024-01-01 00:00:00.000000", "boom", "server.go:1"},
+	}
+
+	for _, tt := range tests {
+		e := ParseLine(tt.raw, 0)
+		if e.Level != tt.wantLevel {
+			t.Errorf("ParseLine(%q) level = %v, want %v", tt.raw, e.Level, tt.wantLevel)
+		}
+		if e.Timestamp != tt.wantTS {
+			t.Errorf("ParseLine(%q) timestamp = %q, want %q", tt.raw, e.Timestamp, tt.wantTS)
+		}
+		if e.Message != tt.wantMsg {
+			t.Errorf("ParseLine(%q) message = %q, want %q", tt.raw, e.Message, tt.wantMsg)
+		}
+		if e.Fields["caller"] != tt.wantCall {
+			t.Errorf("ParseLine(%q) caller = %v, want %q", tt.raw, e.Fields["caller"], tt.wantCall)
+		}
+		if e.IsStack {
+			t.Errorf("ParseLine(%q) marked as stack trace", tt.raw)
+		}
+	}
+}
+
+func TestParseGoPanic(t *testing.T) {
+	tests := []struct {
+		raw       string
+		wantLevel Level
+		wantStack bool
+	}{
+		{"panic: runtime error: index out of range [5] with length 3", LevelFatal, false},
+		{"[signal SIGSEGV: segmentation violation code=0x1 addr=0x0 pc=0x0]", LevelUnknown, true},
+		{"", LevelUnknown, false},
+		{"goroutine 1 [running]:", LevelUnknown, true},
+		{"main.main()", LevelUnknown, true},
+		{"\t/home/dev/api/main.go:12 +0x1d", LevelUnknown, true},
+		{"github.com/acme/api.(*Server).handle(0xc000010000, {0x0, 0x0})", LevelUnknown, true},
+		{"\t/home/dev/api/server.go:42 +0x1d", LevelUnknown, true},
+		{"created by net/http.(*Server).Serve in goroutine 1", LevelUnknown, true},
+		{"\t/usr/local/go/src/net/http/server.go:3285 +0x4b4", LevelUnknown, true},
+		{"exit status 2", LevelUnknown, false},
+		{"fatal error: all goroutines are asleep - deadlock!", LevelFatal, false},
+	}
+
+	lines := make([]string, len(tests))
+	for i, tt := range tests {
+		lines[i] = tt.raw
+	}
+	entries := ParseLines(lines)
+	for _, tt := range tests {
+		if tt.raw == "" {
+			continue
+		}
+		e := entries[0]
+		entries = entries[1:]
+		if e.Level != tt.wantLevel {
+			t.Errorf("Parse(%q) level = %v, want %v", tt.raw, e.Level, tt.wantLevel)
+		}
+		if e.IsStack != tt.wantStack {
+			t.Errorf("Parse(%q) stack = %v, want %v", tt.raw, e.IsStack, tt.wantStack)
+		}
+	}
+}
+
+func TestParseGoFrameShape(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  []bool
+	}{
+		{"call-like message", []string{"cache.refresh(users)", "INFO done"}, []bool{false, false}},
+		{"call-like message alone", []string{"user.Login(alice)"}, []bool{false}},
+		{"frame with file line", []string{"main.run(0x1)", "\t/src/main.go:8 +0x2"}, []bool{true, true}},
+		{"frame without file line", []string{"main.run(0x1)", "\tretrying"}, []bool{false, false}},
+		{"frames after a header", []string{"goroutine 9 [select]:", "main.worker()", "main.other()"}, []bool{true, true, true}},
+		{"header ends at a log line", []string{"goroutine 9 [select]:", "INFO ok", "main.worker()"}, []bool{true, false, false}},
+	}
+
+	for _, tt := range tests {
+		entries := ParseLines(tt.lines)
+		for i, e := range entries {
+			if e.IsStack != tt.want[i] {
+				t.Errorf("%s: %q stack = %v, want %v", tt.name, e.Raw, e.IsStack, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestParserStream(t *testing.T) {
+	p := NewParser()
+	var entries []Entry
+	for _, batch := range [][]string{
+		{"[INFO] starting server", "panic: handler failed"},
+		{"", "goroutine 7 [running]:", "github.com/acme/api.errorHandler(0x0)"},
+		{"\t/home/dev/api/errors.go:12 +0x1d"},
+		{"2024-01-01 10:00:00 ERROR restarted"},
+	} {
+		entries = append(entries, p.Parse(batch)...)
+	}
+
+	want := []struct {
+		index int
+		level Level
+		stack bool
+	}{
+		{0, LevelInfo, false},
+		{1, LevelFatal, false},
+		{3, LevelUnknown, true},
+		{4, LevelUnknown, true},
+		{5, LevelUnknown, true},
+		{6, LevelError, false},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(entries), len(want))
+	}
+	for i, w := range want {
+		e := entries[i]
+		if e.Index != w.index || e.Level != w.level || e.IsStack != w.stack {
+			t.Errorf("entry %d (%q) = index %d level %v stack %v, want %d %v %v", i, e.Raw, e.Index, e.Level, e.IsStack, w.index, w.level, w.stack)
+		}
+	}
+}
+
+func TestParserFrameAcrossBatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		batches [][]string
+		want    []bool
+	}{
+		{"file line in the next batch", [][]string{{"INFO ok", "main.run(0x1)"}, {"\t/src/main.go:8 +0x2", "main.main()"}}, []bool{false, true, true, true}},
+		{"no file line in the next batch", [][]string{{"main.run(0x1)"}, {"INFO done"}}, []bool{false, false}},
+		{"frame left at the end", [][]string{{"INFO ok"}, {"user.Login(alice)"}}, []bool{false, false}},
+		{"candidates across empty batches", [][]string{{"main.run(0x1)"}, {}, {"\t/src/main.go:8 +0x2"}}, []bool{true, true}},
+	}
+
+	for _, tt := range tests {
+		p := NewParser()
+		var entries []Entry
+		for _, batch := range tt.batches {
+			entries = append(entries, p.Parse(batch)...)
+		}
+		if !p.Pending() && tt.name == "frame left at the end" {
+			t.Errorf("%s: trailing frame candidate not held back", tt.name)
+		}
+		entries = append(entries, p.Flush()...)
+		if p.Pending() || len(entries) != len(tt.want) {
+			t.Fatalf("%s: got %d entries (pending %v), want %d", tt.name, len(entries), p.Pending(), len(tt.want))
+		}
+		for i, e := range entries {
+			if e.Index != i || e.IsStack != tt.want[i] {
+				t.Errorf("%s: %q = index %d stack %v, want %d %v", tt.name, e.Raw, e.Index, e.IsStack, i, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestParserKeepsCallerSlice(t *testing.T) {
+	lines := []string{"INFO a", "main.run(0x1)", "INFO b"}
+	p := NewParser()
+	p.Parse(lines[:2])
+	p.Parse([]string{"\t/src/main.go:8 +0x2"})
+	if lines[2] != "INFO b" {
+		t.Errorf("Parse overwrote the caller's slice: %q", lines)
+	}
+}
+
+func TestParseSyslog(t *testing.T) {
+	tests := []struct {
+		raw       string
+		wantLevel Level
+		wantMsg   string
+		wantTS    string
+		wantApp   string
+	}{
+		{`<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog - ID47 [exampleSDID@32473 iut="3"] An application event`, LevelNotice, "An application event", "2003-10-11T22:14:15.003Z", "evntslog"},
+		{`<11>1 - host api 42 - - disk failure`, LevelError, "disk failure", "", "api"},
+		{`<34>Oct 11 22:14:15 mymachine su[230]: 'su root' failed for lonvick on /dev/pts/8`, LevelCritical, "'su root' failed for lonvick on /dev/pts/8", "Oct 11 22:14:15", "su"},
+		{`<14>worker started`, LevelInfo, "worker started", "", ""},
+	}
+
+	for _, tt := range tests {
+		e := ParseLine(tt.raw, 0)
+		if e.Level != tt.wantLevel {
+			t.Errorf("ParseLine(%q) level = %v, want %v", tt.raw, e.Level, tt.wantLevel)
+		}
+		if e.Message != tt.wantMsg {
+			t.Errorf("ParseLine(%q) message = %q, want %q", tt.raw, e.Message, tt.wantMsg)
+		}
+		if e.Timestamp != tt.wantTS {
+			t.Errorf("ParseLine(%q) timestamp = %q, want %q", tt.raw, e.Timestamp, tt.wantTS)
+		}
+		if app, _ := e.Fields["app"].(string); app != tt.wantApp {
+			t.Errorf("ParseLine(%q) app = %q, want %q", tt.raw, app, tt.wantApp)
+		}
+	}
+}
+
+func TestParseJournald(t *testing.T) {
+	raw := `{"__REALTIME_TIMESTAMP":"1714564800123456","PRIORITY":"3","MESSAGE":"upstream timed out","_SYSTEMD_UNIT":"nginx.service","SYSLOG_IDENTIFIER":"nginx"}`
+	e := ParseLine(raw, 0)
+	if e.Message != "upstream timed out" {
+		t.Errorf("ParseLine(journald) message = %q, want upstream timed out", e.Message)
+	}
+	if e.Level != LevelError {
+		t.Errorf("ParseLine(journald) level = %v, want ERROR", e.Level)
+	}
+	if e.Timestamp != "2024-05-01T12:00:00.123456Z" {
+		t.Errorf("ParseLine(journald) timestamp = %q, want 2024-05-01T12:00:00.123456Z", e.Timestamp)
+	}
+	if s := ServiceName(e); s != "nginx.service" {
+		t.Errorf("ServiceName(journald) = %q, want nginx.service", s)
+	}
+	if e.Fields["unit"] != "nginx.service" {
+		t.Errorf("ParseLine(journald) unit = %v, want nginx.service", e.Fields["unit"])
+	}
+	if !NewFilter("unit=nginx.service").MatchEntry(&e) {
+		t.Error("unit=nginx.service does not match the journald entry")
+	}
+
+	tests := []struct {
+		raw, want string
+	}{
+		{`{"MESSAGE":"x","_SYSTEMD_USER_UNIT":"sync.service"}`, "sync.service"},
+		{`{"MESSAGE":"x","_SYSTEMD_UNIT":"nginx.service","unit":"own"}`, "own"},
+	}
+	for _, tt := range tests {
+		if got := ParseLine(tt.raw, 0).Fields["unit"]; got != tt.want {
+			t.Errorf("ParseLine(%q) unit = %v, want %q", tt.raw, got, tt.want)
+		}
+	}
+
+	e = ParseLine(`{"PRIORITY":"6","MESSAGE":[104,105,27,91,48,109]}`, 0)
+	if e.Message != "hi" || e.Level != LevelInfo {
+		t.Errorf("ParseLine(journald bytes) = %q %v, want hi INFO", e.Message, e.Level)
+	}
+}
+
+func TestFieldMapping(t *testing.T) {
+	defer ResetFieldMapping()
+	raw := `{"event":"user signed in","log":{"level":"warn"},"eventTime":"2024-05-01T12:00:00Z"}`
+
+	e := ParseLine(raw, 0)
+	if e.Message == "user signed in" || e.Timestamp != "" {
+		t.Errorf("ParseLine(unmapped) = %q %q, want compact JSON and no timestamp", e.Message, e.Timestamp)
+	}
+
+	SetFieldMapping(FieldMapping{
+		Message:   []string{"event"},
+		Level:     []string{"log.level"},
+		Timestamp: []string{"eventTime"},
+	})
+	e = ParseLine(raw, 0)
+	if e.Message != "user signed in" || e.Level != LevelWarn || e.Timestamp != "2024-05-01T12:00:00Z" {
+		t.Errorf("ParseLine(mapped) = %q %v %q", e.Message, e.Level, e.Timestamp)
+	}
+
+	e = ParseLine(`{"msg":"defaults still apply","level":"error"}`, 0)
+	if e.Message != "defaults still apply" || e.Level != LevelError {
+		t.Errorf("ParseLine(defaults) = %q %v", e.Message, e.Level)
+	}
+}
+
+func TestLinePatterns(t *testing.T) {
+	defer SetLinePatterns(nil)
+	if _, err := CompileLinePattern(`^(\w+) (.*)$`); err == nil {
+		t.Errorf("CompileLinePattern(no named groups) error = nil, want error")
+	}
+	re, err := CompileLinePattern(`^(?P<timestamp>\d{2}:\d{2}:\d{2}) (?P<level>\w+) (?P<component>\w+): (?P<message>.*)$`)
+	if err != nil {
+		t.Fatalf("CompileLinePattern() error = %v", err)
+	}
+	SetLinePatterns([]*regexp.Regexp{re})
+
+	e := ParseLine("12:00:01 warn billing: invoice retry", 0)
+	if e.Message != "invoice retry" || e.Level != LevelWarn || e.Timestamp != "12:00:01" || e.Fields["component"] != "billing" {
+		t.Errorf("ParseLine(pattern) = %q %v %q %v", e.Message, e.Level, e.Timestamp, e.Fields)
+	}
+
+	e = ParseLine("[ERROR] unrelated line", 0)
+	if e.Message != "[ERROR] unrelated line" || e.Level != LevelError || e.Fields != nil {
+		t.Errorf("ParseLine(no match) = %q %v %v", e.Message, e.Level, e.Fields)
+	}
+}
+
+func TestParseLineStripsANSI(t *testing.T) {
+	raw := "\x1b[31mERROR\x1b[0m \x1b]0;title\x07payment failed"
+	e := ParseLine(raw, 0)
+	if e.Raw != "ERROR payment failed" {
+		t.Errorf("Raw = %q, want stripped text", e.Raw)
+	}
+	if want := "\x1b[31mERROR\x1b[0m payment failed"; e.ANSI != want {
+		t.Errorf("ANSI = %q, want %q", e.ANSI, want)
+	}
+	if e.Level != LevelError {
+		t.Errorf("Level = %v, want ERROR", e.Level)
+	}
+
+	plain := ParseLine("INFO ready", 0)
+	if plain.ANSI != "" {
+		t.Errorf("ANSI = %q for plain line, want empty", plain.ANSI)
+	}
+
+	moved := ParseLine("\x1b[2J\x1b[1;1H\x1b[K\rprogress 100%\x1b[?25h", 0)
+	if moved.ANSI != "" || moved.Raw != "\rprogress 100%" {
+		t.Errorf("cursor-only line: ANSI = %q Raw = %q", moved.ANSI, moved.Raw)
+	}
+
+	mixed := ParseLine("\x1b[1;32mok\x1b[0m\x1b[3A\x1b]8;;http://x\x1b\\link\x1b]8;;\x1b\\\a", 0)
+	if want := "\x1b[1;32mok\x1b[0mlink"; mixed.ANSI != want {
+		t.Errorf("ANSI = %q, want %q", mixed.ANSI, want)
+	}
+}
+
+func TestLevelNormalization(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want Level
+	}{
+		{`{"level":"fatal","msg":"x"}`, LevelFatal},
+		{`{"level":"critical","msg":"x"}`, LevelCritical},
+		{`{"level":"notice","msg":"x"}`, LevelNotice},
+		{`{"level":60,"msg":"x"}`, LevelFatal},
+		{`{"level":30,"msg":"x"}`, LevelInfo},
+		{`{"level":1,"msg":"x"}`, LevelUnknown},
+		{`{"level":0,"msg":"x"}`, LevelUnknown},
+		{`{"lvl":"3","msg":"x"}`, LevelUnknown},
+		{`{"severity":2,"msg":"x"}`, LevelCritical},
+		{`{"priority":0,"msg":"x"}`, LevelFatal},
+		{`{"PRIORITY":"3","MESSAGE":"x","priority":"3"}`, LevelError},
+		{`{"severityNumber":21,"body":"x"}`, LevelFatal},
+		{`{"severity_number":9,"body":"x"}`, LevelInfo},
+		{`{"severity_text":"WARN","body":"x"}`, LevelWarn},
+		{"2024-01-15 10:30:45 [NOTICE] config reloaded", LevelNotice},
+		{"2024-01-15 10:30:45 [ALERT] disk failing", LevelAlert},
+		{"FATAL: could not bind port", LevelFatal},
+		{"2024-01-15 10:30:45 NOTICE config reloaded", LevelNotice},
+		{"CRIT: replica lag", LevelCritical},
+		{"level=alert msg=disk", LevelAlert},
+		{`ts=1 level="notice" msg=x`, LevelNotice},
+		{"PANIC worker died", LevelFatal},
+		{"created alert rule for cpu", LevelUnknown},
+		{"please notice the new endpoint", LevelUnknown},
+		{"recovered from panic in handler", LevelUnknown},
+		{"marked as crit by oncall", LevelUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := ParseLine(tt.raw, 0).Level; got != tt.want {
+			t.Errorf("ParseLine(%q) level = %v, want %v", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestLevelAlias(t *testing.T) {
+	defer ResetLevelAliases()
+	SetLevelAlias("sev5", LevelCritical)
+
+	if got := ParseLine(`{"level":"SEV5","msg":"x"}`, 0).Level; got != LevelCritical {
+		t.Errorf("JSON alias level = %v, want CRIT", got)
+	}
+	if got := ParseLine("[SEV5] replica lag", 0).Level; got != LevelCritical {
+		t.Errorf("text alias level = %v, want CRIT", got)
+	}
+	if got := ParseLine("paged sev5 on-call", 0).Level; got != LevelUnknown {
+		t.Errorf("alias in prose level = %v, want UNKNOWN", got)
+	}
+
+	SetLevelAlias("p1", LevelAlert)
+	SetLevelAlias("p3", LevelNotice)
+	SetLevelAlias("p2", LevelWarn)
+	for i := 0; i < 20; i++ {
+		if got := ParseLine("[P3] [P1] queue stalled", 0).Level; got != LevelAlert {
+			t.Fatalf("overlapping aliases level = %v, want ALERT", got)
+		}
+	}
+}
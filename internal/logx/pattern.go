@@ -0,0 +1,64 @@
+package logx
+
+import (
+	"errors"
+	"regexp"
+)
+
+var linePatterns []*regexp.Regexp
+
+func CompileLinePattern(expr string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range re.SubexpNames() {
+		switch name {
+		case "message", "level", "timestamp":
+			return re, nil
+		}
+	}
+	return nil, errors.New("pattern needs a message, level or timestamp named group")
+}
+
+func SetLinePatterns(patterns []*regexp.Regexp) {
+	linePatterns = patterns
+}
+
+func parseLinePattern(entry Entry, line string) (Entry, bool) {
+	for _, re := range linePatterns {
+		m := re.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		fields := make(map[string]any)
+		for i, name := range re.SubexpNames() {
+			if name == "" || m[i] == "" {
+				continue
+			}
+			switch name {
+			case "message":
+				entry.Message = m[i]
+			case "level":
+				if level := normalizeLevel(name, m[i]); level != LevelUnknown {
+					entry.Level = level
+				}
+			case "timestamp":
+				entry.Timestamp = m[i]
+			default:
+				fields[name] = m[i]
+			}
+		}
+		if entry.Message == "" {
+			entry.Message = line
+		}
+		if entry.Level == LevelUnknown {
+			entry.Level = detectLevelText(line)
+		}
+		if len(fields) > 0 {
+			entry.Fields = fields
+		}
+		return entry, true
+	}
+	return entry, false
+}
@@ -0,0 +1,77 @@
+package logx
+
+import (
+	"regexp"
+	"strconv"
+)
+
+var (
+	syslog5424Pattern = regexp.MustCompile(`^<(\d{1,3})>1 (\S+) (\S+) (\S+) (\S+) (\S+) (-|(?:\[(?:[^\]\\]|\\.)*\])+) ?(.*)$`)
+	syslog3164Pattern = regexp.MustCompile(`^<(\d{1,3})>([A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2}) (\S+) ([^\s:\[]+)(?:\[(\d+)\])?: ?(.*)$`)
+	syslogPriPattern  = regexp.MustCompile(`^<(\d{1,3})>(.*)$`)
+)
+
+var syslogFacilities = []string{
+	"kern", "user", "mail", "daemon", "auth", "syslog", "lpr", "news",
+	"uucp", "cron", "authpriv", "ftp", "ntp", "security", "console", "solaris-cron",
+	"local0", "local1", "local2", "local3", "local4", "local5", "local6", "local7",
+}
+
+func parseSyslog(entry Entry, trimmed string) (Entry, bool) {
+	fields := make(map[string]any)
+	var pri string
+
+	if m := syslog5424Pattern.FindStringSubmatch(trimmed); m != nil {
+		pri = m[1]
+		entry.Timestamp = nilValue(m[2])
+		setSyslogField(fields, "host", m[3])
+		setSyslogField(fields, "app", m[4])
+		setSyslogField(fields, "pid", m[5])
+		setSyslogField(fields, "msgid", m[6])
+		setSyslogField(fields, "structured_data", m[7])
+		entry.Message = trimBOM(m[8])
+	} else if m := syslog3164Pattern.FindStringSubmatch(trimmed); m != nil {
+		pri = m[1]
+		entry.Timestamp = m[2]
+		setSyslogField(fields, "host", m[3])
+		setSyslogField(fields, "app", m[4])
+		setSyslogField(fields, "pid", m[5])
+		entry.Message = m[6]
+	} else if m := syslogPriPattern.FindStringSubmatch(trimmed); m != nil {
+		pri = m[1]
+		entry.Message = m[2]
+		entry.Timestamp = extractTimestampText(m[2])
+	} else {
+		return entry, false
+	}
+
+	n, err := strconv.Atoi(pri)
+	if err != nil || n > 191 {
+		return entry, false
+	}
+	fields["facility"] = syslogFacilities[n/8]
+	entry.Level = syslogSeverity(n % 8)
+	entry.Fields = fields
+	entry.IsStack = isStackTrace(entry.Message)
+	return entry, true
+}
+
+func setSyslogField(fields map[string]any, key, val string) {
+	if v := nilValue(val); v != "" {
+		fields[key] = v
+	}
+}
+
+func nilValue(s string) string {
+	if s == "-" {
+		return ""
+	}
+	return s
+}
+
+func trimBOM(s string) string {
+	if len(s) >= 3 && s[:3] == "\xef\xbb\xbf" {
+		return s[3:]
+	}
+	return s
+}
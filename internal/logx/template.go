@@ -0,0 +1,104 @@
+package logx
+
+import (
+	"regexp"
+	"strings"
+)
+
+var templatePatterns = []struct {
+	re          *regexp.Regexp
+	placeholder string
+	hint        func(string) bool
+}{
+	{regexp.MustCompile(`"[^"]*"`), `"<*>"`, func(s string) bool { return strings.Count(s, `"`) >= 2 }},
+	{regexp.MustCompile(`'[^']*'`), `'<*>'`, func(s string) bool { return strings.Count(s, `'`) >= 2 }},
+	{regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`), "<uuid>", func(s string) bool { return strings.Count(s, "-") >= 4 }},
+	{regexp.MustCompile(`\b\d{1,3}(\.\d{1,3}){3}(:\d+)?\b`), "<ip>", func(s string) bool { return strings.Count(s, ".") >= 3 }},
+}
+
+func Template(msg string) string {
+	for _, p := range templatePatterns {
+		if p.hint(msg) {
+			msg = p.re.ReplaceAllString(msg, p.placeholder)
+		}
+	}
+	if strings.IndexAny(msg, "0123456789") >= 0 {
+		msg = maskNumbers(maskHex(msg))
+	}
+	return strings.Join(strings.Fields(msg), " ")
+}
+
+func maskHex(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if !isWordByte(s[i]) {
+			b.WriteByte(s[i])
+			i++
+			continue
+		}
+		j := i
+		for j < len(s) && isWordByte(s[j]) {
+			j++
+		}
+		if isHexWord(s[i:j]) {
+			b.WriteString("<hex>")
+		} else {
+			b.WriteString(s[i:j])
+		}
+		i = j
+	}
+	return b.String()
+}
+
+func isHexWord(w string) bool {
+	if len(w) > 2 && w[0] == '0' && (w[1] == 'x' || w[1] == 'X') && isHexWord(w[2:]) {
+		return true
+	}
+	digit, letter := false, false
+	for i := 0; i < len(w); i++ {
+		switch c := w[i]; {
+		case c >= '0' && c <= '9':
+			digit = true
+		case c >= 'a' && c <= 'f', c >= 'A' && c <= 'F':
+			letter = true
+		default:
+			return false
+		}
+	}
+	return digit && letter
+}
+
+func maskNumbers(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		j := i
+		if (s[j] == '-' || s[j] == '+') && j+1 < len(s) && isDigit(s[j+1]) {
+			j++
+		}
+		if !isDigit(s[j]) {
+			b.WriteByte(s[i])
+			i++
+			continue
+		}
+		for j < len(s) && isDigit(s[j]) {
+			j++
+		}
+		if j+1 < len(s) && s[j] == '.' && isDigit(s[j+1]) {
+			j++
+			for j < len(s) && isDigit(s[j]) {
+				j++
+			}
+		}
+		b.WriteString("<n>")
+		i = j
+	}
+	return b.String()
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isWordByte(c byte) bool {
+	return isDigit(c) || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
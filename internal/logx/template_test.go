@@ -0,0 +1,28 @@
+package logx
+
+import "testing"
+
+func TestTemplate(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want string
+	}{
+		{"connection refused", "connection refused"},
+		{"timeout after 30s", "timeout after <n>s"},
+		{"user 42 not found", "user <n> not found"},
+		{`user "bob" not found`, `user "<*>" not found`},
+		{"request 3f2a9c1e-77aa-4b1c-9d2e-0123456789ab failed", "request <uuid> failed"},
+		{"dial tcp 10.0.0.12:5432: connect: refused", "dial tcp <ip>: connect: refused"},
+		{"commit a1b2c3d4e5 pushed", "commit <hex> pushed"},
+		{"latency 12.5ms  p99", "latency <n>ms p<n>"},
+		{"deadbeef cafe", "deadbeef cafe"},
+		{"fault at 0x1F in worker_2", "fault at <hex> in worker_<n>"},
+		{"offset -3 drift +4.5", "offset <n> drift <n>"},
+	}
+
+	for _, tt := range tests {
+		if got := Template(tt.msg); got != tt.want {
+			t.Errorf("Template(%q) = %q, want %q", tt.msg, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package logx
+
+import "time"
+
+var timeFormats = []string{
+	time.RFC3339,
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.000Z",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05.000",
+	"2006-01-02 15:04:05",
+	"02/Jan/2006:15:04:05",
+	"Jan 02 15:04:05",
+	"15:04:05.000",
+	"15:04:05",
+}
+
+func ParseTime(ts string) time.Time {
+	if ts == "" {
+		return time.Time{}
+	}
+	for _, format := range timeFormats {
+		if t, err := time.Parse(format, ts); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
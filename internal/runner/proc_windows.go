@@ -0,0 +1,15 @@
+//go:build windows
+
+package runner
+
+import "os/exec"
+
+func setProcessGroup(cmd *exec.Cmd) {}
+
+func interrupt(cmd *exec.Cmd) {
+	cmd.Process.Kill()
+}
+
+func kill(cmd *exec.Cmd) {
+	cmd.Process.Kill()
+}
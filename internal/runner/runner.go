@@ -0,0 +1,140 @@
+package runner
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	StreamStdout = "stdout"
+	StreamStderr = "stderr"
+)
+
+type Line struct {
+	Text   string
+	Stream string
+}
+
+type Process struct {
+	Args     []string
+	Started  time.Time
+	Finished time.Time
+	ExitCode int
+	Err      error
+	Lines    <-chan Line
+
+	cmd  *exec.Cmd
+	done chan struct{}
+}
+
+func Start(args []string) (*Process, error) {
+	if len(args) == 0 {
+		return nil, errors.New("no command given")
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	setProcessGroup(cmd)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	lines := make(chan Line, 1000)
+	p := &Process{
+		Args:    args,
+		Started: time.Now(),
+		Lines:   lines,
+		cmd:     cmd,
+		done:    make(chan struct{}),
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go scan(stdout, StreamStdout, lines, &wg)
+	go scan(stderr, StreamStderr, lines, &wg)
+
+	go func() {
+		wg.Wait()
+		err := cmd.Wait()
+		p.Finished = time.Now()
+		p.ExitCode = cmd.ProcessState.ExitCode()
+		var exitErr *exec.ExitError
+		if err != nil && !errors.As(err, &exitErr) {
+			p.Err = err
+		}
+		close(p.done)
+		close(lines)
+	}()
+
+	return p, nil
+}
+
+func (p *Process) Command() string {
+	return strings.Join(p.Args, " ")
+}
+
+func (p *Process) Done() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *Process) Runtime() time.Duration {
+	if p.Done() {
+		return p.Finished.Sub(p.Started)
+	}
+	return time.Since(p.Started)
+}
+
+func (p *Process) Stop() {
+	if p.Done() || p.cmd.Process == nil {
+		return
+	}
+	interrupt(p.cmd)
+	go func() {
+		select {
+		case <-p.done:
+		case <-time.After(3 * time.Second):
+			kill(p.cmd)
+		}
+	}()
+}
+
+func (p *Process) Terminate(timeout time.Duration) {
+	if p.Done() || p.cmd.Process == nil {
+		return
+	}
+	interrupt(p.cmd)
+	select {
+	case <-p.done:
+	case <-time.After(timeout):
+		kill(p.cmd)
+		<-p.done
+	}
+}
+
+func scan(r io.Reader, stream string, lines chan<- Line, wg *sync.WaitGroup) {
+	defer wg.Done()
+	scanner := bufio.NewScanner(r)
+	const maxBuf = 1024 * 1024
+	scanner.Buffer(make([]byte, maxBuf), maxBuf)
+	for scanner.Scan() {
+		lines <- Line{Text: scanner.Text(), Stream: stream}
+	}
+	io.Copy(io.Discard, r)
+}
@@ -0,0 +1,38 @@
+package runner
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestStartCapturesStreams(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+
+	p, err := Start([]string{"sh", "-c", "echo out; echo err >&2; exit 3"})
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	got := map[string]string{}
+	for line := range p.Lines {
+		got[line.Stream] = line.Text
+	}
+
+	if got[StreamStdout] != "out" || got[StreamStderr] != "err" {
+		t.Errorf("Start() lines = %v, want stdout=out stderr=err", got)
+	}
+	if !p.Done() || p.ExitCode != 3 || p.Err != nil {
+		t.Errorf("Start() done=%v exit=%d err=%v, want done exit=3", p.Done(), p.ExitCode, p.Err)
+	}
+}
+
+func TestStartMissingCommand(t *testing.T) {
+	if _, err := Start([]string{"lx-no-such-command"}); err == nil {
+		t.Errorf("Start(missing) error = nil, want error")
+	}
+	if _, err := Start(nil); err == nil {
+		t.Errorf("Start(nil) error = nil, want error")
+	}
+}
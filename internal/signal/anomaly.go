@@ -0,0 +1,205 @@
+package signal
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/kalayciburak/lx/internal/logx"
+)
+
+const (
+	anomalyBuckets   = 20
+	anomalyNewAfter  = 0.1
+	anomalyNewMin    = 3
+	anomalySpikeMin  = 5
+	anomalySpikeRate = 3.0
+)
+
+type templateStats struct {
+	template   string
+	count      int
+	first      int
+	firstPos   int
+	firstAxis  float64
+	firstLevel logx.Level
+	maxLevel   logx.Level
+	escalateAt int
+	buckets    []int
+	bucketPos  []int
+}
+
+func Anomalies(entries []logx.Entry, limit int) *SignalResult {
+	if limit <= 0 {
+		limit = 10
+	}
+	result := &AnomalyResult{}
+
+	axis := make([]float64, len(entries))
+	timed := 0
+	for i, e := range entries {
+		if t := logx.ParseTime(e.Timestamp); !t.IsZero() {
+			axis[i] = float64(t.UnixNano()) / float64(time.Second)
+			timed++
+		} else {
+			axis[i] = math.NaN()
+		}
+	}
+	result.ByTime = timed*2 >= len(entries) && timed > 0
+	if !result.ByTime {
+		for i := range axis {
+			axis[i] = float64(i)
+		}
+	}
+
+	start, end := math.Inf(1), math.Inf(-1)
+	for i, e := range entries {
+		if e.Deleted || math.IsNaN(axis[i]) {
+			continue
+		}
+		start = math.Min(start, axis[i])
+		end = math.Max(end, axis[i])
+	}
+	span := end - start
+
+	cache := make(TemplateCache)
+	stats := make(map[string]*templateStats)
+	for i, e := range entries {
+		if e.Deleted {
+			continue
+		}
+		result.Total++
+		tmpl := cache.Get(e.Message)
+		st := stats[tmpl]
+		if st == nil {
+			st = &templateStats{
+				template:   tmpl,
+				first:      i,
+				firstPos:   i,
+				firstAxis:  math.NaN(),
+				firstLevel: e.Level,
+				escalateAt: -1,
+				buckets:    make([]int, anomalyBuckets),
+				bucketPos:  make([]int, anomalyBuckets),
+			}
+			stats[tmpl] = st
+		}
+		st.count++
+		if e.Level > st.maxLevel {
+			st.maxLevel = e.Level
+			if e.Level > st.firstLevel && st.escalateAt < 0 {
+				st.escalateAt = i
+			}
+		}
+		if math.IsNaN(axis[i]) || span <= 0 {
+			continue
+		}
+		if math.IsNaN(st.firstAxis) || axis[i] < st.firstAxis {
+			st.firstAxis = axis[i]
+			st.firstPos = i
+		}
+		b := int((axis[i] - start) / span * anomalyBuckets)
+		if b >= anomalyBuckets {
+			b = anomalyBuckets - 1
+		}
+		if st.buckets[b] == 0 {
+			st.bucketPos[b] = i
+		}
+		st.buckets[b]++
+	}
+	result.Templates = len(stats)
+
+	for _, st := range stats {
+		a := Anomaly{Template: st.template, Count: st.count, Level: st.maxLevel, Entry: st.first}
+
+		if span > 0 && !math.IsNaN(st.firstAxis) && (st.count >= anomalyNewMin || st.maxLevel >= logx.LevelWarn) {
+			if onset := (st.firstAxis - start) / span; onset >= anomalyNewAfter {
+				a.Score += (1 + onset) * (1 + math.Log10(float64(st.count)))
+				a.Onset = onset
+				a.FirstAt = axisTime(st.firstAxis, result.ByTime)
+				a.Reasons = append(a.Reasons, AnomalyNew)
+				a.Entry = st.firstPos
+			}
+		}
+
+		if peak, ratio := spike(st.buckets); ratio >= anomalySpikeRate {
+			a.Score += math.Log2(ratio)
+			a.SpikeRatio = ratio
+			a.SpikeAt = axisTime(start+span*float64(peak)/anomalyBuckets, result.ByTime)
+			a.Reasons = append(a.Reasons, AnomalySpike)
+			if a.Onset == 0 {
+				a.Entry = st.bucketPos[peak]
+			}
+		}
+
+		if st.escalateAt >= 0 && st.maxLevel >= logx.LevelWarn {
+			a.Score += 1 + float64(st.maxLevel-st.firstLevel)/2
+			a.FromLevel = st.firstLevel
+			a.Reasons = append(a.Reasons, AnomalyEscalation)
+			if a.Onset == 0 && a.SpikeRatio == 0 {
+				a.Entry = st.escalateAt
+			}
+		}
+
+		if a.Score == 0 {
+			continue
+		}
+		switch {
+		case st.maxLevel.IsError():
+			a.Score *= 2
+		case st.maxLevel == logx.LevelWarn:
+			a.Score *= 1.5
+		}
+		result.Items = append(result.Items, a)
+	}
+
+	sort.Slice(result.Items, func(i, j int) bool {
+		if result.Items[i].Score != result.Items[j].Score {
+			return result.Items[i].Score > result.Items[j].Score
+		}
+		return result.Items[i].Template < result.Items[j].Template
+	})
+	if len(result.Items) > limit {
+		result.Items = result.Items[:limit]
+	}
+
+	return &SignalResult{
+		Type:      SignalAnomaly,
+		Title:     "Anomalies",
+		Anomalies: result,
+	}
+}
+
+func spike(buckets []int) (peak int, ratio float64) {
+	first, last := -1, -1
+	for i, n := range buckets {
+		if n > 0 {
+			if first < 0 {
+				first = i
+			}
+			last = i
+		}
+		if n > buckets[peak] {
+			peak = i
+		}
+	}
+	if buckets[peak] < anomalySpikeMin || first == last {
+		return peak, 0
+	}
+
+	rest := 0
+	for i := first; i <= last; i++ {
+		if i != peak {
+			rest += buckets[i]
+		}
+	}
+	baseline := float64(rest) / float64(last-first)
+	return peak, float64(buckets[peak]) / (baseline + 1)
+}
+
+func axisTime(v float64, byTime bool) time.Time {
+	if !byTime {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(v*float64(time.Second))).UTC()
+}
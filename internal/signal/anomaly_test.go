@@ -0,0 +1,150 @@
+package signal
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kalayciburak/lx/internal/logx"
+)
+
+func logEntry(off int, level logx.Level, msg string) logx.Entry {
+	return logx.Entry{
+		Message:   msg,
+		Level:     level,
+		Timestamp: testStart.Add(time.Duration(off) * time.Second).Format(time.RFC3339),
+	}
+}
+
+func background(from, to, step int) []logx.Entry {
+	var entries []logx.Entry
+	for off := from; off <= to; off += step {
+		entries = append(entries, logEntry(off, logx.LevelInfo, "request served"))
+	}
+	return entries
+}
+
+func findAnomaly(r *AnomalyResult, template string) (int, *Anomaly) {
+	for i := range r.Items {
+		if r.Items[i].Template == template {
+			return i, &r.Items[i]
+		}
+	}
+	return -1, nil
+}
+
+func hasReason(a *Anomaly, reason AnomalyReason) bool {
+	for _, r := range a.Reasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSpike(t *testing.T) {
+	tests := []struct {
+		name      string
+		buckets   []int
+		wantPeak  int
+		wantRatio float64
+	}{
+		{"flat", []int{6, 6, 6, 6, 6}, 0, 6.0 / 7},
+		{"single bucket", []int{0, 0, 50, 0, 0}, 2, 0},
+		{"peak below minimum", []int{1, 0, 4, 0, 1}, 2, 0},
+		{"clear spike", []int{1, 1, 1, 10, 1, 1}, 3, 5},
+		{"spike at the edge", []int{0, 2, 2, 2, 2, 12}, 5, 4},
+	}
+
+	for _, tt := range tests {
+		peak, ratio := spike(tt.buckets)
+		if peak != tt.wantPeak || math.Abs(ratio-tt.wantRatio) > 1e-9 {
+			t.Errorf("%s: spike(%v) = %d, %.3f, want %d, %.3f", tt.name, tt.buckets, peak, ratio, tt.wantPeak, tt.wantRatio)
+		}
+	}
+}
+
+func TestAnomaliesRanking(t *testing.T) {
+	entries := background(0, 1000, 10)
+	for off := 0; off <= 1000; off += 100 {
+		entries = append(entries, logEntry(off, logx.LevelError, "db timeout after 30s"))
+	}
+	for off := 500; off < 540; off += 4 {
+		entries = append(entries, logEntry(off, logx.LevelError, "db timeout after 45s"))
+	}
+	entries = append(entries, logEntry(995, logx.LevelInfo, "shutdown complete"))
+	entries = append(entries, logEntry(700, logx.LevelError, "disk full"))
+
+	r := Anomalies(entries, 10).Anomalies
+	if !r.ByTime {
+		t.Fatal("expected time axis")
+	}
+	if i, _ := findAnomaly(r, "shutdown complete"); i >= 0 {
+		t.Errorf("single late INFO line ranked at %d", i)
+	}
+
+	i, a := findAnomaly(r, "db timeout after <n>s")
+	if i != 0 || !hasReason(a, AnomalySpike) {
+		t.Fatalf("db timeout spike at rank %d (%+v), want first", i, a)
+	}
+	if got := int(a.SpikeAt.Sub(testStart) / time.Second); got != 500 {
+		t.Errorf("spike at %ds, want 500s", got)
+	}
+
+	i, a = findAnomaly(r, "disk full")
+	if i < 0 || !hasReason(a, AnomalyNew) {
+		t.Fatalf("disk full not reported as new: %+v", r.Items)
+	}
+	if math.Abs(a.Onset-0.7) > 1e-9 {
+		t.Errorf("disk full onset = %.3f, want 0.7", a.Onset)
+	}
+}
+
+func TestAnomaliesOnsetUsesEarliestTime(t *testing.T) {
+	entries := background(0, 1000, 50)
+	late := len(entries)
+	entries = append(entries,
+		logEntry(900, logx.LevelWarn, "cache miss"),
+		logEntry(910, logx.LevelWarn, "cache miss"),
+		logEntry(20, logx.LevelWarn, "cache miss"),
+		logEntry(800, logx.LevelError, "disk full"),
+		logEntry(600, logx.LevelError, "disk full"),
+	)
+
+	r := Anomalies(entries, 10).Anomalies
+	if _, a := findAnomaly(r, "cache miss"); a != nil && hasReason(a, AnomalyNew) {
+		t.Errorf("cache miss first seen at 20s reported as new: %+v", a)
+	}
+
+	_, a := findAnomaly(r, "disk full")
+	if a == nil || !hasReason(a, AnomalyNew) {
+		t.Fatalf("disk full not reported as new: %+v", r.Items)
+	}
+	if math.Abs(a.Onset-0.6) > 1e-9 {
+		t.Errorf("disk full onset = %.3f, want 0.6", a.Onset)
+	}
+	if a.Entry != late+4 {
+		t.Errorf("disk full entry = %d, want %d", a.Entry, late+4)
+	}
+}
+
+func TestAnomaliesEscalation(t *testing.T) {
+	entries := background(0, 1000, 50)
+	entries = append(entries,
+		logEntry(10, logx.LevelInfo, "retrying upstream"),
+		logEntry(20, logx.LevelInfo, "retrying upstream"),
+		logEntry(30, logx.LevelError, "retrying upstream"),
+	)
+
+	r := Anomalies(entries, 10).Anomalies
+	_, a := findAnomaly(r, "retrying upstream")
+	if a == nil || !hasReason(a, AnomalyEscalation) {
+		t.Fatalf("escalation not reported: %+v", r.Items)
+	}
+	if a.FromLevel != logx.LevelInfo || a.Level != logx.LevelError {
+		t.Errorf("escalation %v → %v, want INFO → ERROR", a.FromLevel, a.Level)
+	}
+	if a.Entry != len(entries)-1 {
+		t.Errorf("entry = %d, want %d", a.Entry, len(entries)-1)
+	}
+}
@@ -1,22 +1,26 @@
 package signal
 
 import (
+	"sort"
 	"time"
 
 	"github.com/kalayciburak/lx/internal/logx"
 )
 
-var timeFormats = []string{
-	time.RFC3339,
-	time.RFC3339Nano,
-	"2006-01-02T15:04:05.000Z",
-	"2006-01-02T15:04:05",
-	"2006-01-02 15:04:05.000",
-	"2006-01-02 15:04:05",
-	"02/Jan/2006:15:04:05",
-	"Jan 02 15:04:05",
-	"15:04:05.000",
-	"15:04:05",
+type BurstWindow struct {
+	Seconds   int
+	Threshold int
+}
+
+var BurstWindows = []BurstWindow{
+	{10, 5},
+	{30, 8},
+	{60, 15},
+}
+
+type burstPoint struct {
+	time time.Time
+	pos  int
 }
 
 func DetectBurst(entries []logx.Entry, targetMsg string) *SignalResult {
@@ -32,103 +36,128 @@ func DetectBurst(entries []logx.Entry, targetMsg string) *SignalResult {
 		}
 	}
 
-	var times []time.Time
-	for _, e := range entries {
+	var points []burstPoint
+	for i, e := range entries {
 		if e.Deleted || e.Message != targetMsg {
 			continue
 		}
-		if t := parseTimestamp(e.Timestamp); !t.IsZero() {
-			times = append(times, t)
+		if t := logx.ParseTime(e.Timestamp); !t.IsZero() {
+			points = append(points, burstPoint{time: t, pos: i})
 		}
 	}
 
-	if len(times) < 2 {
+	if len(points) < 2 {
 		return &SignalResult{
 			Type:  SignalBurst,
 			Title: "Burst Detector",
 			Burst: &BurstResult{
 				Message:     truncateMsg(targetMsg, 50),
 				Detected:    false,
-				Count:       len(times),
+				Count:       len(points),
 				Description: "Not enough data points with timestamps",
 			},
 		}
 	}
 
-	type windowConfig struct {
-		seconds   int
-		threshold int
+	sort.SliceStable(points, func(i, j int) bool { return points[i].time.Before(points[j].time) })
+
+	var bursts []BurstInterval
+	for _, w := range BurstWindows {
+		bursts = append(bursts, findBursts(points, w)...)
 	}
-	windows := []windowConfig{
-		{10, 5},
-		{30, 8},
-		{60, 15},
+	bursts = mergeBursts(points, bursts)
+
+	if len(bursts) == 0 {
+		return &SignalResult{
+			Type:  SignalBurst,
+			Title: "Burst Detector",
+			Burst: &BurstResult{
+				Message:     truncateMsg(targetMsg, 50),
+				Detected:    false,
+				Count:       len(points),
+				Description: "No abnormal burst pattern detected",
+			},
+		}
 	}
 
-	for _, w := range windows {
-		maxCount, _ := findMaxInWindow(times, time.Duration(w.seconds)*time.Second)
-		if maxCount >= w.threshold {
-			return &SignalResult{
-				Type:  SignalBurst,
-				Title: "Burst Detector",
-				Burst: &BurstResult{
-					Message:     truncateMsg(targetMsg, 50),
-					Detected:    true,
-					Count:       maxCount,
-					WindowSecs:  w.seconds,
-					Description: itoa(maxCount) + " occurrences in " + itoa(w.seconds) + "s window",
-				},
-			}
+	peak := bursts[0]
+	for _, b := range bursts[1:] {
+		if b.Count > peak.Count {
+			peak = b
 		}
 	}
+	desc := itoa(peak.Count) + " occurrences over " + peak.Span()
+	if len(bursts) > 1 {
+		desc = itoa(len(bursts)) + " bursts, largest " + desc
+	}
 
 	return &SignalResult{
 		Type:  SignalBurst,
 		Title: "Burst Detector",
 		Burst: &BurstResult{
 			Message:     truncateMsg(targetMsg, 50),
-			Detected:    false,
-			Count:       len(times),
-			Description: "No abnormal burst pattern detected",
+			Detected:    true,
+			Count:       peak.Count,
+			WindowSecs:  peak.WindowSecs,
+			Description: desc,
+			Bursts:      bursts,
 		},
 	}
 }
 
-func findMaxInWindow(times []time.Time, window time.Duration) (maxCount int, windowStart time.Time) {
-	if len(times) == 0 {
-		return 0, time.Time{}
+func findBursts(points []burstPoint, w BurstWindow) []BurstInterval {
+	window := time.Duration(w.Seconds) * time.Second
+	var bursts []BurstInterval
+	open := -1
+	left := 0
+	for right := range points {
+		for points[right].time.Sub(points[left].time) > window {
+			left++
+		}
+		if right-left+1 < w.Threshold {
+			continue
+		}
+		if open >= 0 && left <= bursts[open].last {
+			bursts[open].last = right
+			continue
+		}
+		bursts = append(bursts, BurstInterval{WindowSecs: w.Seconds, first: left, last: right})
+		open = len(bursts) - 1
 	}
+	return bursts
+}
 
-	maxCount = 1
-	windowStart = times[0]
+func mergeBursts(points []burstPoint, bursts []BurstInterval) []BurstInterval {
+	sort.Slice(bursts, func(i, j int) bool {
+		if bursts[i].first != bursts[j].first {
+			return bursts[i].first < bursts[j].first
+		}
+		return bursts[i].WindowSecs < bursts[j].WindowSecs
+	})
 
-	for i := 0; i < len(times); i++ {
-		count := 1
-		windowEnd := times[i].Add(window)
-		for j := i + 1; j < len(times); j++ {
-			if times[j].Before(windowEnd) || times[j].Equal(windowEnd) {
-				count++
+	var merged []BurstInterval
+	for _, b := range bursts {
+		if n := len(merged); n > 0 && b.first <= merged[n-1].last {
+			if b.last > merged[n-1].last {
+				merged[n-1].last = b.last
 			}
+			continue
 		}
-		if count > maxCount {
-			maxCount = count
-			windowStart = times[i]
-		}
+		merged = append(merged, b)
 	}
 
-	return maxCount, windowStart
+	for i := range merged {
+		b := &merged[i]
+		b.Start = points[b.first].time
+		b.End = points[b.last].time
+		b.Count = b.last - b.first + 1
+		b.Entry = points[b.first].pos
+	}
+	return merged
 }
 
-func parseTimestamp(ts string) time.Time {
-	if ts == "" {
-		return time.Time{}
-	}
-	for _, format := range timeFormats {
-		if t, err := time.Parse(format, ts); err == nil {
-			return t
-		}
-	}
-	return time.Time{}
+func (b BurstInterval) Span() string {
+	return FormatDuration(b.End.Sub(b.Start))
 }
 
 func truncateMsg(msg string, maxLen int) string {
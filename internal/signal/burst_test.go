@@ -0,0 +1,138 @@
+package signal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kalayciburak/lx/internal/logx"
+)
+
+var testStart = time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+func timedEntries(msg string, offsets ...int) []logx.Entry {
+	entries := make([]logx.Entry, len(offsets))
+	for i, off := range offsets {
+		entries[i] = logx.Entry{
+			Index:     i,
+			Message:   msg,
+			Level:     logx.LevelError,
+			Timestamp: testStart.Add(time.Duration(off) * time.Second).Format(time.RFC3339),
+		}
+	}
+	return entries
+}
+
+func withBurstWindows(t *testing.T, windows ...BurstWindow) {
+	saved := BurstWindows
+	BurstWindows = windows
+	t.Cleanup(func() { BurstWindows = saved })
+}
+
+type burstSpan struct {
+	start, end, count, entry int
+}
+
+func checkBursts(t *testing.T, got []BurstInterval, want []burstSpan) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d bursts %+v, want %d", len(got), got, len(want))
+	}
+	for i, w := range want {
+		b := got[i]
+		start := int(b.Start.Sub(testStart) / time.Second)
+		end := int(b.End.Sub(testStart) / time.Second)
+		if start != w.start || end != w.end || b.Count != w.count || b.Entry != w.entry {
+			t.Errorf("burst %d = %ds..%ds count %d entry %d, want %ds..%ds count %d entry %d",
+				i, start, end, b.Count, b.Entry, w.start, w.end, w.count, w.entry)
+		}
+	}
+}
+
+func TestDetectBurst(t *testing.T) {
+	tests := []struct {
+		name    string
+		windows []BurstWindow
+		offsets []int
+		want    []burstSpan
+	}{
+		{
+			name:    "sorted",
+			windows: []BurstWindow{{10, 3}},
+			offsets: []int{0, 100, 101, 102, 200},
+			want:    []burstSpan{{100, 102, 3, 1}},
+		},
+		{
+			name:    "unsorted input",
+			windows: []BurstWindow{{10, 3}},
+			offsets: []int{200, 102, 0, 100, 101},
+			want:    []burstSpan{{100, 102, 3, 3}},
+		},
+		{
+			name:    "window slides over a long run",
+			windows: []BurstWindow{{10, 3}},
+			offsets: []int{0, 5, 10, 15, 20, 25},
+			want:    []burstSpan{{0, 25, 6, 0}},
+		},
+		{
+			name:    "overlapping windows of different sizes merge",
+			windows: []BurstWindow{{10, 3}, {60, 5}},
+			offsets: []int{0, 2, 4, 30, 50, 55, 300},
+			want:    []burstSpan{{0, 55, 6, 0}},
+		},
+		{
+			name:    "adjacent bursts stay separate",
+			windows: []BurstWindow{{10, 3}},
+			offsets: []int{0, 1, 2, 15, 16, 17},
+			want:    []burstSpan{{0, 2, 3, 0}, {15, 17, 3, 3}},
+		},
+		{
+			name:    "below threshold",
+			windows: []BurstWindow{{10, 3}},
+			offsets: []int{0, 20, 40, 60},
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withBurstWindows(t, tt.windows...)
+			r := DetectBurst(timedEntries("db timeout", tt.offsets...), "db timeout").Burst
+			if r.Detected != (len(tt.want) > 0) {
+				t.Fatalf("Detected = %v, want %v", r.Detected, len(tt.want) > 0)
+			}
+			checkBursts(t, r.Bursts, tt.want)
+		})
+	}
+}
+
+func TestDetectBurstEntryPosition(t *testing.T) {
+	withBurstWindows(t, BurstWindow{10, 3})
+	entries := timedEntries("db timeout", 0, 1, 2)
+	other := logx.Entry{Message: "other", Timestamp: entries[0].Timestamp}
+	deleted := entries[0]
+	deleted.Deleted = true
+	entries = append([]logx.Entry{other, deleted, other}, entries...)
+
+	r := DetectBurst(entries, "db timeout").Burst
+	checkBursts(t, r.Bursts, []burstSpan{{0, 2, 3, 3}})
+}
+
+func TestDetectBurstDescription(t *testing.T) {
+	tests := []struct {
+		name    string
+		offsets []int
+		want    string
+	}{
+		{"single window", []int{0, 1, 2, 100}, "3 occurrences over 2.000s"},
+		{"merged run longer than the window", []int{0, 5, 10, 15, 20, 25, 90}, "6 occurrences over 25.000s"},
+		{"several bursts", []int{0, 1, 2, 100, 101, 102, 103, 300}, "2 bursts, largest 4 occurrences over 3.000s"},
+	}
+
+	for _, tt := range tests {
+		withBurstWindows(t, BurstWindow{10, 3})
+		r := DetectBurst(timedEntries("db timeout", tt.offsets...), "db timeout").Burst
+		if r.Description != tt.want {
+			t.Errorf("%s: Description = %q, want %q", tt.name, r.Description, tt.want)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package signal
+
+import (
+	"math"
+	"sort"
+
+	"github.com/kalayciburak/lx/internal/logx"
+)
+
+const (
+	compareMinCount = 5
+	compareRatio    = 2.0
+	compareExamples = 3
+)
+
+type TemplateCache map[string]string
+
+func (c TemplateCache) Get(msg string) string {
+	tmpl, ok := c[msg]
+	if !ok {
+		tmpl = logx.Template(msg)
+		c[msg] = tmpl
+	}
+	return tmpl
+}
+
+func Compare(baseline, current []logx.Entry, cache TemplateCache) *CompareResult {
+	if cache == nil {
+		cache = make(TemplateCache)
+	}
+	result := &CompareResult{}
+	base := compareSides(baseline, cache, &result.BaseTotal)
+	cur := compareSides(current, cache, &result.CurrentTotal)
+	result.BaseTemplates = len(base)
+	result.CurrentTemplates = len(cur)
+
+	for tmpl, c := range cur {
+		item := CompareItem{Template: tmpl, Current: *c}
+		b, ok := base[tmpl]
+		if !ok {
+			item.Status = CompareNew
+			result.Items = append(result.Items, item)
+			continue
+		}
+		item.Base = *b
+		if item.classify(result.BaseTotal, result.CurrentTotal) {
+			result.Items = append(result.Items, item)
+		} else {
+			result.Unchanged++
+		}
+	}
+	for tmpl, b := range base {
+		if _, ok := cur[tmpl]; !ok {
+			result.Items = append(result.Items, CompareItem{Template: tmpl, Status: CompareGone, Base: *b})
+		}
+	}
+
+	sort.Slice(result.Items, func(i, j int) bool {
+		a, b := result.Items[i], result.Items[j]
+		if a.Status != b.Status {
+			return a.Status < b.Status
+		}
+		if a.Status == CompareMore || a.Status == CompareLess {
+			if ra, rb := math.Abs(math.Log(a.Ratio)), math.Abs(math.Log(b.Ratio)); ra != rb {
+				return ra > rb
+			}
+		}
+		if la, lb := a.Level(), b.Level(); la != lb {
+			return la > lb
+		}
+		if ca, cb := a.Base.Count+a.Current.Count, b.Base.Count+b.Current.Count; ca != cb {
+			return ca > cb
+		}
+		return a.Template < b.Template
+	})
+
+	return result
+}
+
+func compareSides(entries []logx.Entry, cache TemplateCache, total *int) map[string]*CompareSide {
+	sides := make(map[string]*CompareSide)
+	for i, e := range entries {
+		if e.Deleted {
+			continue
+		}
+		*total++
+		tmpl := cache.Get(e.Message)
+		side := sides[tmpl]
+		if side == nil {
+			side = &CompareSide{Entry: i}
+			sides[tmpl] = side
+		}
+		side.Count++
+		if e.Level > side.Level {
+			side.Level = e.Level
+		}
+		if len(side.Examples) < compareExamples && !containsString(side.Examples, e.Message) {
+			side.Examples = append(side.Examples, e.Message)
+		}
+	}
+	return sides
+}
+
+func (item *CompareItem) classify(baseTotal, currentTotal int) bool {
+	if item.Current.Level != item.Base.Level && (item.Current.Level >= logx.LevelWarn || item.Base.Level >= logx.LevelWarn) {
+		item.Status = CompareLevel
+		return true
+	}
+	if item.Base.Count < compareMinCount && item.Current.Count < compareMinCount {
+		return false
+	}
+	baseRate := float64(item.Base.Count) / float64(baseTotal)
+	curRate := float64(item.Current.Count) / float64(currentTotal)
+	item.Ratio = curRate / baseRate
+	switch {
+	case item.Ratio >= compareRatio:
+		item.Status = CompareMore
+	case item.Ratio <= 1/compareRatio:
+		item.Status = CompareLess
+	default:
+		return false
+	}
+	return true
+}
+
+func (item CompareItem) Level() logx.Level {
+	if item.Current.Level > item.Base.Level {
+		return item.Current.Level
+	}
+	return item.Base.Level
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package signal
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kalayciburak/lx/internal/logx"
+)
+
+func compareEntries(level logx.Level, msg string, n int) []logx.Entry {
+	entries := make([]logx.Entry, n)
+	for i := range entries {
+		entries[i] = logx.Entry{Message: msg + " " + itoa(i+1) + "ms", Level: level}
+	}
+	return entries
+}
+
+func join(groups ...[]logx.Entry) []logx.Entry {
+	var entries []logx.Entry
+	for _, g := range groups {
+		entries = append(entries, g...)
+	}
+	return entries
+}
+
+func TestCompare(t *testing.T) {
+	baseline := join(
+		compareEntries(logx.LevelInfo, "login ok in", 20),
+		compareEntries(logx.LevelError, "db timeout after", 5),
+		compareEntries(logx.LevelInfo, "cache miss after", 10),
+		compareEntries(logx.LevelInfo, "disk slow for", 5),
+		compareEntries(logx.LevelInfo, "old job ran in", 3),
+	)
+	current := join(
+		compareEntries(logx.LevelInfo, "login ok in", 20),
+		compareEntries(logx.LevelError, "db timeout after", 20),
+		compareEntries(logx.LevelInfo, "cache miss after", 2),
+		compareEntries(logx.LevelWarn, "disk slow for", 5),
+		compareEntries(logx.LevelError, "panic in handler after", 1),
+	)
+
+	r := Compare(baseline, current, nil)
+	if r.BaseTotal != 43 || r.CurrentTotal != 48 || r.BaseTemplates != 5 || r.CurrentTemplates != 5 || r.Unchanged != 1 {
+		t.Errorf("totals %d/%d templates %d/%d unchanged %d", r.BaseTotal, r.CurrentTotal, r.BaseTemplates, r.CurrentTemplates, r.Unchanged)
+	}
+	var got []CompareStatus
+	var examples []string
+	for _, item := range r.Items {
+		got = append(got, item.Status)
+		examples = append(examples, append(item.Current.Examples, item.Base.Examples...)[0])
+	}
+	want := []CompareStatus{CompareNew, CompareLevel, CompareMore, CompareLess, CompareGone}
+	wantExamples := []string{"panic in handler after 1ms", "disk slow for 1ms", "db timeout after 1ms", "cache miss after 1ms", "old job ran in 1ms"}
+	if !reflect.DeepEqual(got, want) || !reflect.DeepEqual(examples, wantExamples) {
+		t.Errorf("items = %v %q, want %v %q", got, examples, want, wantExamples)
+	}
+	if more := r.Items[2]; more.Ratio < 3.5 || more.Ratio > 3.6 || len(more.Current.Examples) != compareExamples {
+		t.Errorf("more = ratio %v examples %v", more.Ratio, more.Current.Examples)
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name        string
+		base, cur   int
+		baseLevel   logx.Level
+		curLevel    logx.Level
+		want        CompareStatus
+		interesting bool
+	}{
+		{"twice as often", 5, 10, logx.LevelInfo, logx.LevelInfo, CompareMore, true},
+		{"half as often", 10, 5, logx.LevelInfo, logx.LevelInfo, CompareLess, true},
+		{"below the ratio", 10, 19, logx.LevelInfo, logx.LevelInfo, 0, false},
+		{"above half", 10, 6, logx.LevelInfo, logx.LevelInfo, 0, false},
+		{"one side reaches the minimum", 4, 8, logx.LevelInfo, logx.LevelInfo, CompareMore, true},
+		{"both below the minimum", 1, 4, logx.LevelInfo, logx.LevelInfo, 0, false},
+		{"level raised to error", 1, 1, logx.LevelInfo, logx.LevelError, CompareLevel, true},
+		{"level lowered from warn", 10, 10, logx.LevelWarn, logx.LevelInfo, CompareLevel, true},
+		{"level change below warn", 10, 10, logx.LevelDebug, logx.LevelInfo, 0, false},
+	}
+
+	for _, tt := range tests {
+		item := CompareItem{
+			Base:    CompareSide{Count: tt.base, Level: tt.baseLevel},
+			Current: CompareSide{Count: tt.cur, Level: tt.curLevel},
+		}
+		if got := item.classify(100, 100); got != tt.interesting || got && item.Status != tt.want {
+			t.Errorf("%s: classify = %v status %v, want %v %v", tt.name, got, item.Status, tt.interesting, tt.want)
+		}
+	}
+}
+
+func TestCompareCache(t *testing.T) {
+	template := logx.Template("db timeout after 30ms")
+	cache := TemplateCache{"upstream gave up": template}
+	baseline := []logx.Entry{{Message: "upstream gave up", Level: logx.LevelError}}
+	current := []logx.Entry{{Message: "db timeout after 45ms", Level: logx.LevelError}}
+
+	r := Compare(baseline, current, cache)
+	if len(r.Items) != 0 || r.Unchanged != 1 {
+		t.Errorf("items %v unchanged %d, want the cached template on both sides", r.Items, r.Unchanged)
+	}
+	if cache["db timeout after 45ms"] != template {
+		t.Errorf("cache = %v, want the current side stored", cache)
+	}
+}
@@ -0,0 +1,130 @@
+package signal
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kalayciburak/lx/internal/logx"
+)
+
+type CorrelatedEvent struct {
+	Workspace int
+	Index     int
+	Entry     logx.Entry
+	Service   string
+	Time      time.Time
+}
+
+type CorrelationResult struct {
+	Key      string
+	Value    string
+	Events   []CorrelatedEvent
+	Services []string
+	Start    time.Time
+	Elapsed  time.Duration
+	HasTime  bool
+}
+
+func Correlate(workspaces [][]logx.Entry, id logx.CorrelationID) *CorrelationResult {
+	result := &CorrelationResult{Key: id.Key, Value: id.Value}
+	seenService := make(map[string]bool)
+
+	for w, entries := range workspaces {
+		for i, e := range entries {
+			if e.Deleted || !logx.HasCorrelationID(e, id.Value) {
+				continue
+			}
+			service := logx.ServiceName(e)
+			if service == "" {
+				service = "ws" + itoa(w+1)
+			}
+			if !seenService[service] {
+				seenService[service] = true
+				result.Services = append(result.Services, service)
+			}
+			result.Events = append(result.Events, CorrelatedEvent{
+				Workspace: w,
+				Index:     i,
+				Entry:     e,
+				Service:   service,
+				Time:      logx.ParseTime(e.Timestamp),
+			})
+		}
+	}
+
+	sort.SliceStable(result.Events, func(i, j int) bool {
+		a, b := result.Events[i].Time, result.Events[j].Time
+		if a.IsZero() || b.IsZero() {
+			return !a.IsZero() && b.IsZero()
+		}
+		return a.Before(b)
+	})
+
+	for _, ev := range result.Events {
+		if ev.Time.IsZero() {
+			continue
+		}
+		if !result.HasTime {
+			result.Start = ev.Time
+			result.HasTime = true
+		}
+		result.Elapsed = ev.Time.Sub(result.Start)
+	}
+
+	return result
+}
+
+func (r *CorrelationResult) Offset(ev CorrelatedEvent) (time.Duration, bool) {
+	if !r.HasTime || ev.Time.IsZero() {
+		return 0, false
+	}
+	return ev.Time.Sub(r.Start), true
+}
+
+func (r *CorrelationResult) FormatForClipboard() string {
+	var sb strings.Builder
+	sb.WriteString("CORRELATION " + r.Key + "=" + r.Value + "\n")
+	sb.WriteString("Events: " + itoa(len(r.Events)) + "  Services: " + strings.Join(r.Services, ", "))
+	if r.HasTime {
+		sb.WriteString("  Elapsed: " + FormatDuration(r.Elapsed))
+	}
+	sb.WriteString("\n\n")
+	for _, ev := range r.Events {
+		offset := "      ?"
+		if d, ok := r.Offset(ev); ok {
+			offset = "+" + FormatDuration(d)
+		}
+		sb.WriteString(offset + " [" + ev.Service + "] [" + ev.Entry.Level.String() + "] " + ev.Entry.Message + "\n")
+	}
+	return sb.String()
+}
+
+func FormatDuration(d time.Duration) string {
+	switch {
+	case d > 0 && d < time.Millisecond:
+		return itoa(int(d.Microseconds())) + "µs"
+	case d < time.Second:
+		return itoa(int(d.Milliseconds())) + "ms"
+	case d < time.Minute:
+		ms := int(d.Milliseconds())
+		frac := itoa(ms % 1000)
+		for len(frac) < 3 {
+			frac = "0" + frac
+		}
+		return itoa(ms/1000) + "." + frac + "s"
+	case d < time.Hour:
+		secs := int(d.Seconds())
+		return itoa(secs/60) + "m" + pad2(secs%60) + "s"
+	default:
+		mins := int(d.Minutes())
+		return itoa(mins/60) + "h" + pad2(mins%60) + "m"
+	}
+}
+
+func pad2(n int) string {
+	if n < 10 {
+		return "0" + itoa(n)
+	}
+	return itoa(n)
+}
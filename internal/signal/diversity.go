@@ -7,15 +7,19 @@ import (
 func Diversity(entries []logx.Entry) *SignalResult {
 	uniqueMessages := make(map[string]struct{})
 	totalErrors := 0
+	fatalCount := 0
 
 	for _, e := range entries {
 		if e.Deleted {
 			continue
 		}
-		if e.Level == logx.LevelError {
+		if e.Level.IsError() {
 			totalErrors++
 			uniqueMessages[e.Message] = struct{}{}
 		}
+		if e.Level == logx.LevelFatal {
+			fatalCount++
+		}
 	}
 
 	uniqueCount := len(uniqueMessages)
@@ -33,6 +37,7 @@ func Diversity(entries []logx.Entry) *SignalResult {
 		Diversity: &DiversityResult{
 			TotalErrors:   totalErrors,
 			UniqueErrors:  uniqueCount,
+			FatalCount:    fatalCount,
 			Ratio:         ratio,
 			Quality:       quality,
 			QualityReason: reason,
@@ -12,9 +12,13 @@ func ErrorFrequency(entries []logx.Entry, limit int) *SignalResult {
 	}
 
 	counts := make(map[string]int)
+	levels := make(map[string]logx.Level)
 	for _, e := range entries {
-		if e.Level == logx.LevelError && !e.Deleted {
+		if e.Level.IsError() && !e.Deleted {
 			counts[e.Message]++
+			if e.Level > levels[e.Message] {
+				levels[e.Message] = e.Level
+			}
 		}
 	}
 
@@ -23,11 +27,15 @@ func ErrorFrequency(entries []logx.Entry, limit int) *SignalResult {
 		results = append(results, FrequencyResult{
 			Message: msg,
 			Count:   count,
+			Level:   levels[msg],
 		})
 	}
 
 	sort.Slice(results, func(i, j int) bool {
-		return results[i].Count > results[j].Count
+		if results[i].Count != results[j].Count {
+			return results[i].Count > results[j].Count
+		}
+		return results[i].Level > results[j].Level
 	})
 
 	if len(results) > limit {
@@ -1,9 +1,18 @@
 package signal
 
 import (
+	"sort"
+	"time"
+
 	"github.com/kalayciburak/lx/internal/logx"
 )
 
+const (
+	periodicMinGaps   = 3
+	periodicTolerance = 0.2
+	periodicShare     = 0.8
+)
+
 func Lifetime(entries []logx.Entry, targetMsg string) *SignalResult {
 	if targetMsg == "" {
 		return &SignalResult{
@@ -13,21 +22,28 @@ func Lifetime(entries []logx.Entry, targetMsg string) *SignalResult {
 				Message:     "",
 				Occurrences: 0,
 				IsSingle:    true,
+				FirstEntry:  -1,
+				LastEntry:   -1,
 			},
 		}
 	}
 
-	var timestamps []string
+	var stamped, seen []int
+	var points []burstPoint
 	count := 0
 
-	for _, e := range entries {
+	for i, e := range entries {
 		if e.Deleted {
 			continue
 		}
 		if e.Message == targetMsg {
 			count++
+			seen = append(seen, i)
 			if e.Timestamp != "" {
-				timestamps = append(timestamps, e.Timestamp)
+				stamped = append(stamped, i)
+			}
+			if t := logx.ParseTime(e.Timestamp); !t.IsZero() {
+				points = append(points, burstPoint{time: t, pos: i})
 			}
 		}
 	}
@@ -35,20 +51,32 @@ func Lifetime(entries []logx.Entry, targetMsg string) *SignalResult {
 	result := &LifetimeResult{
 		Message:     targetMsg,
 		Occurrences: count,
+		IsSingle:    count <= 1,
+		Timed:       len(points),
+		FirstEntry:  -1,
+		LastEntry:   -1,
 	}
 
-	if len(timestamps) == 0 {
-		result.IsSingle = true
+	switch {
+	case len(points) > 0:
+		sort.SliceStable(points, func(i, j int) bool { return points[i].time.Before(points[j].time) })
+		first, last := points[0], points[len(points)-1]
+		result.FirstEntry, result.LastEntry = first.pos, last.pos
+		result.FirstSeen = entries[first.pos].Timestamp
+		result.LastSeen = entries[last.pos].Timestamp
+		result.FirstAt, result.LastAt = first.time, last.time
+		result.measureGaps(points)
+		result.measureTail(entries, last.time)
+	case len(stamped) > 0:
+		result.FirstEntry, result.LastEntry = stamped[0], stamped[len(stamped)-1]
+		result.FirstSeen = entries[result.FirstEntry].Timestamp
+		result.LastSeen = entries[result.LastEntry].Timestamp
+	default:
+		if len(seen) > 0 {
+			result.FirstEntry, result.LastEntry = seen[0], seen[len(seen)-1]
+		}
 		result.FirstSeen = "no timestamp"
 		result.LastSeen = "no timestamp"
-	} else if len(timestamps) == 1 {
-		result.IsSingle = true
-		result.FirstSeen = timestamps[0]
-		result.LastSeen = timestamps[0]
-	} else {
-		result.IsSingle = false
-		result.FirstSeen = timestamps[0]
-		result.LastSeen = timestamps[len(timestamps)-1]
 	}
 
 	return &SignalResult{
@@ -57,3 +85,81 @@ func Lifetime(entries []logx.Entry, targetMsg string) *SignalResult {
 		Lifetime: result,
 	}
 }
+
+func (r *LifetimeResult) measureGaps(points []burstPoint) {
+	if len(points) < 2 {
+		return
+	}
+	r.Span = r.LastAt.Sub(r.FirstAt)
+	r.MeanGap = r.Span / time.Duration(len(points)-1)
+
+	gaps := make([]time.Duration, 0, len(points)-1)
+	for i := 1; i < len(points); i++ {
+		gap := points[i].time.Sub(points[i-1].time)
+		gaps = append(gaps, gap)
+		if gap > r.MaxGap {
+			r.MaxGap = gap
+			r.MaxGapAt = points[i-1].time
+		}
+	}
+
+	if len(gaps) < periodicMinGaps {
+		return
+	}
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i] < gaps[j] })
+	median := gaps[len(gaps)/2]
+	if median <= 0 {
+		return
+	}
+	tolerance := time.Duration(float64(median) * periodicTolerance)
+	if res := resolution(points); tolerance < res {
+		tolerance = res
+	}
+	regular := 0
+	for _, gap := range gaps {
+		if gap >= median-tolerance && gap <= median+tolerance {
+			regular++
+		}
+	}
+	if float64(regular) >= periodicShare*float64(len(gaps)) {
+		r.Periodic = true
+		r.Period = median
+	}
+}
+
+func resolution(points []burstPoint) time.Duration {
+	res := time.Second
+	for _, p := range points {
+		switch ns := p.time.Nanosecond(); {
+		case ns%int(time.Millisecond) != 0:
+			return time.Microsecond
+		case ns != 0:
+			res = time.Millisecond
+		}
+	}
+	return res
+}
+
+func (r *LifetimeResult) measureTail(entries []logx.Entry, last time.Time) {
+	end := last
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Deleted {
+			continue
+		}
+		if t := logx.ParseTime(entries[i].Timestamp); !t.IsZero() {
+			if t.After(end) {
+				end = t
+			}
+			break
+		}
+	}
+	r.Tail = end.Sub(last)
+	if r.Timed < 2 {
+		return
+	}
+	expected := 2 * r.MeanGap
+	if r.Periodic {
+		expected = r.Period + r.Period/2
+	}
+	r.Ongoing = r.Tail <= expected
+}
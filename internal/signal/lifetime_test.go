@@ -0,0 +1,101 @@
+package signal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kalayciburak/lx/internal/logx"
+)
+
+func gapEntries(msg string, gaps ...time.Duration) []logx.Entry {
+	t := testStart
+	entries := []logx.Entry{{Message: msg, Timestamp: t.Format(time.RFC3339Nano)}}
+	for _, gap := range gaps {
+		t = t.Add(gap)
+		entries = append(entries, logx.Entry{Message: msg, Timestamp: t.Format(time.RFC3339Nano)})
+	}
+	return entries
+}
+
+func repeat(gap time.Duration, n int) []time.Duration {
+	gaps := make([]time.Duration, n)
+	for i := range gaps {
+		gaps[i] = gap
+	}
+	return gaps
+}
+
+func TestLifetimeGaps(t *testing.T) {
+	ms := time.Millisecond
+	tests := []struct {
+		name       string
+		gaps       []time.Duration
+		wantSpan   time.Duration
+		wantMax    time.Duration
+		wantPeriod time.Duration
+	}{
+		{"retry loop", append(repeat(30*time.Second, 8), 32*time.Second, 30*time.Second), 302 * time.Second, 32 * time.Second, 30 * time.Second},
+		{"one long pause", append(repeat(30*time.Second, 8), 10*time.Minute), 14 * time.Minute, 10 * time.Minute, 30 * time.Second},
+		{"irregular", []time.Duration{time.Second, 40 * time.Second, 5 * time.Second, 2 * time.Minute, 12 * time.Second}, 178 * time.Second, 2 * time.Minute, 0},
+		{"high rate jitter", []time.Duration{10 * ms, 480 * ms, 50 * ms, 900 * ms, 45 * ms, 300 * ms, 60 * ms}, 1845 * ms, 900 * ms, 0},
+		{"high rate steady", []time.Duration{50 * ms, 52 * ms, 48 * ms, 50 * ms, 51 * ms}, 251 * ms, 52 * ms, 50 * ms},
+		{"too few gaps", repeat(30*time.Second, 2), time.Minute, 30 * time.Second, 0},
+	}
+
+	for _, tt := range tests {
+		r := Lifetime(gapEntries("retrying upstream", tt.gaps...), "retrying upstream").Lifetime
+		if r.Span != tt.wantSpan || r.MaxGap != tt.wantMax {
+			t.Errorf("%s: span %v max %v, want %v %v", tt.name, r.Span, r.MaxGap, tt.wantSpan, tt.wantMax)
+		}
+		if want := tt.wantSpan / time.Duration(len(tt.gaps)); r.MeanGap != want {
+			t.Errorf("%s: mean gap %v, want %v", tt.name, r.MeanGap, want)
+		}
+		if r.Periodic != (tt.wantPeriod > 0) || r.Period != tt.wantPeriod {
+			t.Errorf("%s: periodic %v every %v, want every %v", tt.name, r.Periodic, r.Period, tt.wantPeriod)
+		}
+	}
+}
+
+func TestLifetimeTimeOrder(t *testing.T) {
+	entries := gapEntries("disk full", repeat(time.Minute, 3)...)
+	entries[0], entries[3] = entries[3], entries[0]
+	entries = append([]logx.Entry{{Message: "disk full", Deleted: true}}, entries...)
+
+	r := Lifetime(entries, "disk full").Lifetime
+	if r.Occurrences != 4 || r.Timed != 4 {
+		t.Fatalf("occurrences %d timed %d, want 4 4", r.Occurrences, r.Timed)
+	}
+	if r.FirstEntry != 4 || r.LastEntry != 1 {
+		t.Errorf("first entry %d last entry %d, want 4 1", r.FirstEntry, r.LastEntry)
+	}
+	if !r.FirstAt.Equal(testStart) || r.Span != 3*time.Minute {
+		t.Errorf("first at %v span %v", r.FirstAt, r.Span)
+	}
+}
+
+func TestLifetimeTail(t *testing.T) {
+	tests := []struct {
+		name        string
+		gaps        []time.Duration
+		logEnd      time.Duration
+		wantTail    time.Duration
+		wantOngoing bool
+	}{
+		{"last line", repeat(10*time.Second, 5), 0, 0, true},
+		{"within the period", repeat(30*time.Second, 5), 2*time.Minute + 40*time.Second, 10 * time.Second, true},
+		{"missed a period", repeat(30*time.Second, 5), 3*time.Minute + 30*time.Second, time.Minute, false},
+		{"irregular, recent", []time.Duration{time.Second, 20 * time.Second, 3 * time.Second}, 40 * time.Second, 16 * time.Second, true},
+		{"irregular, stopped", []time.Duration{time.Second, 20 * time.Second, 3 * time.Second}, 10 * time.Minute, 10*time.Minute - 24*time.Second, false},
+	}
+
+	for _, tt := range tests {
+		entries := gapEntries("retrying upstream", tt.gaps...)
+		if tt.logEnd > 0 {
+			entries = append(entries, logx.Entry{Message: "shutdown", Timestamp: testStart.Add(tt.logEnd).Format(time.RFC3339)})
+		}
+		r := Lifetime(entries, "retrying upstream").Lifetime
+		if r.Tail != tt.wantTail || r.Ongoing != tt.wantOngoing {
+			t.Errorf("%s: tail %v ongoing %v, want %v %v", tt.name, r.Tail, r.Ongoing, tt.wantTail, tt.wantOngoing)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package signal
+
+import (
+	"sort"
+	"time"
+
+	"github.com/kalayciburak/lx/internal/logx"
+)
+
+var rateIntervals = []time.Duration{
+	time.Second,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+	time.Minute,
+	5 * time.Minute,
+	10 * time.Minute,
+	30 * time.Minute,
+	time.Hour,
+	6 * time.Hour,
+	24 * time.Hour,
+}
+
+func RateTimeline(entries []logx.Entry, template, label string, buckets int, cache TemplateCache) *SignalResult {
+	if cache == nil {
+		cache = make(TemplateCache)
+	}
+	result := &RateResult{Label: label, Template: template}
+
+	var times []time.Time
+	for _, e := range entries {
+		if e.Deleted {
+			continue
+		}
+		if template != "" && cache.Get(e.Message) != template {
+			continue
+		}
+		result.Total++
+		if t := logx.ParseTime(e.Timestamp); !t.IsZero() {
+			times = append(times, t)
+		}
+	}
+	result.Untimed = result.Total - len(times)
+
+	if len(times) > 0 {
+		sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+		start, end := times[0], times[len(times)-1]
+		result.Interval = rateInterval(end.Sub(start), buckets)
+		result.Start = start.Truncate(result.Interval)
+		result.Counts = make([]int, int(end.Sub(result.Start)/result.Interval)+1)
+		for _, t := range times {
+			result.Counts[int(t.Sub(result.Start)/result.Interval)]++
+		}
+
+		var active []int
+		for i, n := range result.Counts {
+			if n > result.Peak {
+				result.Peak = n
+				result.PeakIdx = i
+			}
+			if n > 0 {
+				active = append(active, n)
+			}
+		}
+		sort.Ints(active)
+		if len(active)%2 == 1 {
+			result.Median = float64(active[len(active)/2])
+		} else {
+			result.Median = float64(active[len(active)/2-1]+active[len(active)/2]) / 2
+		}
+	}
+
+	return &SignalResult{
+		Type:  SignalRate,
+		Title: "Rate Timeline",
+		Rate:  result,
+	}
+}
+
+func rateInterval(span time.Duration, buckets int) time.Duration {
+	if buckets < 2 {
+		buckets = 2
+	}
+	for _, d := range rateIntervals {
+		if span/d < time.Duration(buckets-1) {
+			return d
+		}
+	}
+	day := rateIntervals[len(rateIntervals)-1]
+	return day * (span/day/time.Duration(buckets-1) + 1)
+}
+
+func (r *RateResult) PeakStart() time.Time {
+	return r.Start.Add(time.Duration(r.PeakIdx) * r.Interval)
+}
+
+func (r *RateResult) PeakRatio() float64 {
+	if r.Median == 0 {
+		return 0
+	}
+	return float64(r.Peak) / r.Median
+}
+
+func FormatInterval(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour && d%(24*time.Hour) == 0:
+		return itoa(int(d/(24*time.Hour))) + "d"
+	case d >= time.Hour && d%time.Hour == 0:
+		return itoa(int(d/time.Hour)) + "h"
+	case d >= time.Minute && d%time.Minute == 0:
+		return itoa(int(d/time.Minute)) + "m"
+	}
+	return itoa(int(d/time.Second)) + "s"
+}
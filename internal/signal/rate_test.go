@@ -0,0 +1,111 @@
+package signal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kalayciburak/lx/internal/logx"
+)
+
+func TestRateInterval(t *testing.T) {
+	tests := []struct {
+		span    time.Duration
+		buckets int
+		want    time.Duration
+	}{
+		{0, 60, time.Second},
+		{58 * time.Second, 60, time.Second},
+		{59 * time.Second, 60, 5 * time.Second},
+		{10 * time.Minute, 60, 30 * time.Second},
+		{3 * time.Hour, 60, 5 * time.Minute},
+		{30 * 24 * time.Hour, 60, 24 * time.Hour},
+		{200 * 24 * time.Hour, 60, 4 * 24 * time.Hour},
+		{time.Second, 1, 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := rateInterval(tt.span, tt.buckets); got != tt.want {
+			t.Errorf("rateInterval(%v, %d) = %v, want %v", tt.span, tt.buckets, got, tt.want)
+		}
+	}
+}
+
+func TestRateTimeline(t *testing.T) {
+	var entries []logx.Entry
+	for i, off := range []int{0, 0, 1, 10, 10, 10, 20} {
+		entries = append(entries, logEntry(off, logx.LevelError, "timeout after "+itoa(30+i)+"ms"))
+	}
+	deleted := logEntry(10, logx.LevelError, "timeout after 99ms")
+	deleted.Deleted = true
+	entries = append(entries,
+		logx.Entry{Message: "timeout after 12ms"},
+		logEntry(5, logx.LevelInfo, "cache warm"),
+		deleted,
+	)
+	template := logx.Template("timeout after 30ms")
+
+	r := RateTimeline(entries, template, "timeout", 60, nil).Rate
+	if r.Total != 8 || r.Untimed != 1 {
+		t.Errorf("Total %d Untimed %d, want 8 and 1", r.Total, r.Untimed)
+	}
+	if r.Interval != time.Second || !r.Start.Equal(testStart) || len(r.Counts) != 21 {
+		t.Fatalf("interval %v start %v with %d buckets", r.Interval, r.Start, len(r.Counts))
+	}
+	for i, want := range map[int]int{0: 2, 1: 1, 5: 0, 10: 3, 20: 1} {
+		if r.Counts[i] != want {
+			t.Errorf("Counts[%d] = %d, want %d", i, r.Counts[i], want)
+		}
+	}
+	if r.Peak != 3 || r.PeakIdx != 10 || !r.PeakStart().Equal(testStart.Add(10*time.Second)) {
+		t.Errorf("peak %d at %d (%v)", r.Peak, r.PeakIdx, r.PeakStart())
+	}
+	if r.Median != 1.5 || r.PeakRatio() != 2 {
+		t.Errorf("median %v ratio %v, want 1.5 and 2", r.Median, r.PeakRatio())
+	}
+
+	all := RateTimeline(entries, "", "all", 60, nil).Rate
+	if all.Total != 9 || all.Counts[5] != 1 {
+		t.Errorf("all lines: Total %d Counts[5] %d, want 9 and 1", all.Total, all.Counts[5])
+	}
+}
+
+func TestRateTimelineBuckets(t *testing.T) {
+	entries := []logx.Entry{
+		logEntry(3, logx.LevelInfo, "tick"),
+		logEntry(200, logx.LevelInfo, "tick"),
+	}
+
+	r := RateTimeline(entries, "", "all", 60, nil).Rate
+	if r.Interval != 5*time.Second || !r.Start.Equal(testStart) || len(r.Counts) != 41 {
+		t.Fatalf("interval %v start %v with %d buckets", r.Interval, r.Start, len(r.Counts))
+	}
+	if r.Counts[0] != 1 || r.Counts[40] != 1 || r.PeakIdx != 0 || r.PeakRatio() != 1 {
+		t.Errorf("counts %d/%d peak at %d ratio %v", r.Counts[0], r.Counts[40], r.PeakIdx, r.PeakRatio())
+	}
+}
+
+func TestRateTimelineUntimed(t *testing.T) {
+	entries := []logx.Entry{{Message: "tick"}, {Message: "tick"}}
+
+	r := RateTimeline(entries, "", "all", 60, nil).Rate
+	if r.Total != 2 || r.Untimed != 2 || r.Counts != nil || r.Peak != 0 || r.PeakRatio() != 0 {
+		t.Errorf("untimed result = %+v", r)
+	}
+}
+
+func TestRateTimelineCache(t *testing.T) {
+	template := logx.Template("timeout after 30ms")
+	cache := TemplateCache{"upstream gave up": template}
+	entries := []logx.Entry{
+		logEntry(0, logx.LevelError, "timeout after 30ms"),
+		logEntry(1, logx.LevelError, "upstream gave up"),
+	}
+
+	r := RateTimeline(entries, template, "timeout", 60, cache).Rate
+	if r.Total != 2 {
+		t.Errorf("Total = %d, want the cached template to match", r.Total)
+	}
+	if cache["timeout after 30ms"] != template {
+		t.Errorf("cache = %v, want the computed template stored", cache)
+	}
+}
@@ -0,0 +1,150 @@
+package signal
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kalayciburak/lx/internal/logx"
+)
+
+const (
+	statsHistogramBins = 10
+	statsGroupLimit    = 10
+)
+
+func NumericStats(entries []logx.Entry, field, groupBy string) *SignalResult {
+	result := &StatsResult{Field: field, GroupBy: groupBy}
+	var values []float64
+	groups := make(map[string][]float64)
+
+	for _, e := range entries {
+		if e.Deleted {
+			continue
+		}
+		val, ok := logx.LookupField(e.Fields, field)
+		if !ok {
+			continue
+		}
+		n, ok := numericValue(val)
+		if !ok {
+			result.Skipped++
+			continue
+		}
+		values = append(values, n)
+		if groupBy != "" {
+			key := "(none)"
+			if g, ok := logx.LookupField(e.Fields, groupBy); ok {
+				key = logx.FieldValue(g)
+			}
+			groups[key] = append(groups[key], n)
+		}
+	}
+
+	result.StatsSummary = summarize(values)
+	result.Histogram = histogram(values, statsHistogramBins)
+
+	for key, vals := range groups {
+		result.Groups = append(result.Groups, StatsGroup{Value: key, StatsSummary: summarize(vals)})
+	}
+	sort.Slice(result.Groups, func(i, j int) bool {
+		if result.Groups[i].P99 != result.Groups[j].P99 {
+			return result.Groups[i].P99 > result.Groups[j].P99
+		}
+		return result.Groups[i].Value < result.Groups[j].Value
+	})
+	if len(result.Groups) > statsGroupLimit {
+		result.Groups = result.Groups[:statsGroupLimit]
+	}
+
+	return &SignalResult{
+		Type:  SignalStats,
+		Title: "Numeric Stats",
+		Stats: result,
+	}
+}
+
+func numericValue(val any) (float64, bool) {
+	switch v := val.(type) {
+	case float64:
+		return v, true
+	case string:
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			return 0, false
+		}
+		return n, true
+	}
+	return 0, false
+}
+
+func IsNumeric(s string) bool {
+	_, ok := numericValue(s)
+	return ok
+}
+
+func summarize(values []float64) StatsSummary {
+	if len(values) == 0 {
+		return StatsSummary{}
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, v := range sorted {
+		sum += v
+	}
+	return StatsSummary{
+		Count: len(sorted),
+		Min:   sorted[0],
+		Max:   sorted[len(sorted)-1],
+		Mean:  sum / float64(len(sorted)),
+		P50:   percentile(sorted, 50),
+		P90:   percentile(sorted, 90),
+		P99:   percentile(sorted, 99),
+	}
+}
+
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func histogram(values []float64, bins int) []HistogramBin {
+	if len(values) == 0 {
+		return nil
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	if lo == hi {
+		return []HistogramBin{{Low: lo, High: hi, Count: len(values)}}
+	}
+
+	width := (hi - lo) / float64(bins)
+	result := make([]HistogramBin, bins)
+	for i := range result {
+		result[i].Low = lo + float64(i)*width
+		result[i].High = lo + float64(i+1)*width
+	}
+	for _, v := range values {
+		i := int((v - lo) / width)
+		if i >= bins {
+			i = bins - 1
+		}
+		result[i].Count++
+	}
+	return result
+}
+
+func FormatNumber(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
@@ -0,0 +1,125 @@
+package signal
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/kalayciburak/lx/internal/logx"
+)
+
+func fieldEntry(fields map[string]any) logx.Entry {
+	return logx.Entry{Message: "request", Fields: fields}
+}
+
+func TestNumericStats(t *testing.T) {
+	var entries []logx.Entry
+	for i := 1; i <= 99; i++ {
+		entries = append(entries, fieldEntry(map[string]any{"ms": float64(i)}))
+	}
+	entries = append(entries,
+		fieldEntry(map[string]any{"ms": "100"}),
+		fieldEntry(map[string]any{"ms": "n/a"}),
+		fieldEntry(map[string]any{"ms": true}),
+		fieldEntry(map[string]any{"route": "/a"}),
+		logx.Entry{Deleted: true, Fields: map[string]any{"ms": float64(5000)}},
+	)
+
+	r := NumericStats(entries, "ms", "").Stats
+	want := StatsSummary{Count: 100, Min: 1, Max: 100, Mean: 50.5, P50: 50, P90: 90, P99: 99}
+	if r.StatsSummary != want {
+		t.Errorf("summary = %+v, want %+v", r.StatsSummary, want)
+	}
+	if r.Skipped != 2 {
+		t.Errorf("Skipped = %d, want 2", r.Skipped)
+	}
+	if r.Groups != nil {
+		t.Errorf("Groups = %v without group-by", r.Groups)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		sorted        []float64
+		p50, p90, p99 float64
+	}{
+		{[]float64{7}, 7, 7, 7},
+		{[]float64{1, 2}, 1, 2, 2},
+		{[]float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 5, 9, 10},
+		{[]float64{10, 20, 30, 40, 50}, 30, 50, 50},
+	}
+
+	for _, tt := range tests {
+		got := []float64{percentile(tt.sorted, 50), percentile(tt.sorted, 90), percentile(tt.sorted, 99)}
+		if want := []float64{tt.p50, tt.p90, tt.p99}; !reflect.DeepEqual(got, want) {
+			t.Errorf("percentiles of %v = %v, want %v", tt.sorted, got, want)
+		}
+	}
+}
+
+func TestNumericStatsSingleValue(t *testing.T) {
+	r := NumericStats([]logx.Entry{fieldEntry(map[string]any{"ms": float64(42)})}, "ms", "").Stats
+	want := StatsSummary{Count: 1, Min: 42, Max: 42, Mean: 42, P50: 42, P90: 42, P99: 42}
+	if r.StatsSummary != want {
+		t.Errorf("summary = %+v, want %+v", r.StatsSummary, want)
+	}
+	if !reflect.DeepEqual(r.Histogram, []HistogramBin{{Low: 42, High: 42, Count: 1}}) {
+		t.Errorf("Histogram = %v, want one bin", r.Histogram)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	var values []float64
+	for i := 0; i <= 10; i++ {
+		values = append(values, float64(i))
+	}
+	values = append(values, 0.5)
+
+	bins := histogram(values, statsHistogramBins)
+	if len(bins) != statsHistogramBins {
+		t.Fatalf("len = %d, want %d", len(bins), statsHistogramBins)
+	}
+	wantCounts := []int{2, 1, 1, 1, 1, 1, 1, 1, 1, 2}
+	for i, b := range bins {
+		if b.Low != float64(i) || b.High != float64(i+1) || b.Count != wantCounts[i] {
+			t.Errorf("bin %d = %+v, want %d..%d count %d", i, b, i, i+1, wantCounts[i])
+		}
+	}
+	if histogram(nil, statsHistogramBins) != nil {
+		t.Error("histogram of no values not nil")
+	}
+}
+
+func TestNumericStatsGroupLimit(t *testing.T) {
+	var entries []logx.Entry
+	for i := 0; i < statsGroupLimit+2; i++ {
+		host := "h" + strconv.Itoa(i/10) + strconv.Itoa(i%10)
+		entries = append(entries,
+			fieldEntry(map[string]any{"ms": float64(i), "host": host}),
+			fieldEntry(map[string]any{"ms": float64(i), "host": host}),
+		)
+	}
+	entries = append(entries,
+		fieldEntry(map[string]any{"ms": float64(500)}),
+		fieldEntry(map[string]any{"ms": float64(11), "host": "h99"}),
+	)
+
+	r := NumericStats(entries, "ms", "host").Stats
+	if len(r.Groups) != statsGroupLimit {
+		t.Fatalf("len(Groups) = %d, want %d", len(r.Groups), statsGroupLimit)
+	}
+	var got []string
+	for _, g := range r.Groups {
+		got = append(got, g.Value)
+	}
+	want := []string{"(none)", "h11", "h99", "h10", "h09", "h08", "h07", "h06", "h05", "h04"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("groups = %v, want %v", got, want)
+	}
+	if g := r.Groups[1]; g.Count != 2 || g.P99 != 11 {
+		t.Errorf("h11 = %+v", g.StatsSummary)
+	}
+	if r.Count != 2*(statsGroupLimit+2)+2 {
+		t.Errorf("Count = %d, grouping changed the overall summary", r.Count)
+	}
+}
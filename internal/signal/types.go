@@ -1,5 +1,12 @@
 package signal
 
+import (
+	"strconv"
+	"time"
+
+	"github.com/kalayciburak/lx/internal/logx"
+)
+
 type SignalType int
 
 const (
@@ -7,11 +14,15 @@ const (
 	SignalLifetime
 	SignalBurst
 	SignalDiversity
+	SignalStats
+	SignalRate
+	SignalAnomaly
 )
 
 type FrequencyResult struct {
 	Message string
 	Count   int
+	Level   logx.Level
 }
 
 type LifetimeResult struct {
@@ -20,6 +31,19 @@ type LifetimeResult struct {
 	LastSeen    string
 	Occurrences int
 	IsSingle    bool
+	FirstEntry  int
+	LastEntry   int
+	Timed       int
+	FirstAt     time.Time
+	LastAt      time.Time
+	Span        time.Duration
+	MeanGap     time.Duration
+	MaxGap      time.Duration
+	MaxGapAt    time.Time
+	Periodic    bool
+	Period      time.Duration
+	Tail        time.Duration
+	Ongoing     bool
 }
 
 type BurstResult struct {
@@ -28,16 +52,134 @@ type BurstResult struct {
 	Count       int
 	WindowSecs  int
 	Description string
+	Bursts      []BurstInterval
+}
+
+type BurstInterval struct {
+	Start      time.Time
+	End        time.Time
+	Count      int
+	WindowSecs int
+	Entry      int
+	first      int
+	last       int
 }
 
 type DiversityResult struct {
 	TotalErrors   int
 	UniqueErrors  int
+	FatalCount    int
 	Ratio         float64
 	Quality       string
 	QualityReason string
 }
 
+type StatsSummary struct {
+	Count int
+	Min   float64
+	Max   float64
+	Mean  float64
+	P50   float64
+	P90   float64
+	P99   float64
+}
+
+type HistogramBin struct {
+	Low   float64
+	High  float64
+	Count int
+}
+
+type StatsGroup struct {
+	Value string
+	StatsSummary
+}
+
+type StatsResult struct {
+	Field     string
+	GroupBy   string
+	Skipped   int
+	Histogram []HistogramBin
+	Groups    []StatsGroup
+	StatsSummary
+}
+
+type RateResult struct {
+	Label    string
+	Template string
+	Total    int
+	Untimed  int
+	Start    time.Time
+	Interval time.Duration
+	Counts   []int
+	Peak     int
+	PeakIdx  int
+	Median   float64
+}
+
+type AnomalyReason int
+
+const (
+	AnomalyNew AnomalyReason = iota
+	AnomalySpike
+	AnomalyEscalation
+)
+
+type Anomaly struct {
+	Template   string
+	Count      int
+	Level      logx.Level
+	Score      float64
+	Reasons    []AnomalyReason
+	Onset      float64
+	FirstAt    time.Time
+	SpikeRatio float64
+	SpikeAt    time.Time
+	FromLevel  logx.Level
+	Entry      int
+}
+
+type AnomalyResult struct {
+	Total     int
+	Templates int
+	ByTime    bool
+	Items     []Anomaly
+}
+
+type CompareStatus int
+
+const (
+	CompareNew CompareStatus = iota
+	CompareLevel
+	CompareMore
+	CompareLess
+	CompareGone
+)
+
+type CompareSide struct {
+	Count    int
+	Level    logx.Level
+	Examples []string
+	Entry    int
+}
+
+type CompareItem struct {
+	Template string
+	Status   CompareStatus
+	Ratio    float64
+	Base     CompareSide
+	Current  CompareSide
+}
+
+type CompareResult struct {
+	BaseTotal        int
+	CurrentTotal     int
+	BaseTemplates    int
+	CurrentTemplates int
+	Unchanged        int
+	Items            []CompareItem
+}
+
 type SignalResult struct {
 	Type       SignalType
 	Title      string
@@ -45,6 +187,9 @@ type SignalResult struct {
 	Lifetime   *LifetimeResult
 	Burst      *BurstResult
 	Diversity  *DiversityResult
+	Stats      *StatsResult
+	Rate       *RateResult
+	Anomalies  *AnomalyResult
 }
 
 func (r *SignalResult) FormatForClipboard() string {
@@ -57,6 +202,12 @@ func (r *SignalResult) FormatForClipboard() string {
 		return formatBurstClipboard(r.Burst)
 	case SignalDiversity:
 		return formatDiversityClipboard(r.Diversity)
+	case SignalStats:
+		return formatStatsClipboard(r.Stats)
+	case SignalRate:
+		return formatRateClipboard(r.Rate)
+	case SignalAnomaly:
+		return formatAnomalyClipboard(r.Anomalies)
 	}
 	return ""
 }
@@ -68,7 +219,7 @@ func formatFrequencyClipboard(results []FrequencyResult) string {
 	var s string
 	s = "TOP ERROR SIGNALS\n\n"
 	for _, r := range results {
-		s += r.Message + " x" + itoa(r.Count) + "\n"
+		s += "[" + r.Level.String() + "] " + r.Message + " x" + itoa(r.Count) + "\n"
 	}
 	return s
 }
@@ -86,9 +237,36 @@ func formatLifetimeClipboard(r *LifetimeResult) string {
 		s += "Last seen:  " + r.LastSeen + "\n"
 	}
 	s += "Occurrences: " + itoa(r.Occurrences) + "\n"
+	if r.Timed >= 2 {
+		s += "Span: " + FormatDuration(r.Span) + "\n"
+		s += "Mean gap: " + FormatDuration(r.MeanGap) + "\n"
+		s += "Max gap: " + FormatDuration(r.MaxGap) + " after " + r.MaxGapAt.Format("2006-01-02 15:04:05") + "\n"
+		s += "Pattern: " + r.Pattern() + "\n"
+		s += "Status: " + r.Status() + "\n"
+	}
 	return s
 }
 
+func (r *LifetimeResult) Pattern() string {
+	if r.Periodic {
+		return "periodic, every ~" + FormatDuration(r.Period)
+	}
+	if r.Timed-1 < periodicMinGaps {
+		return "too few occurrences to tell"
+	}
+	return "irregular"
+}
+
+func (r *LifetimeResult) Status() string {
+	if r.Ongoing {
+		if r.Tail == 0 {
+			return "still occurring at the end of the log"
+		}
+		return "still occurring, last seen " + FormatDuration(r.Tail) + " before the end"
+	}
+	return "stopped " + FormatDuration(r.Tail) + " before the end of the log"
+}
+
 func formatBurstClipboard(r *BurstResult) string {
 	if r == nil {
 		return ""
@@ -97,7 +275,10 @@ func formatBurstClipboard(r *BurstResult) string {
 	s += "Message: " + r.Message + "\n"
 	if r.Detected {
 		s += "BURST DETECTED\n"
-		s += r.Description + "\n"
+		s += r.Description + "\n\n"
+		for _, b := range r.Bursts {
+			s += b.Start.Format(time.RFC3339) + " - " + b.End.Format(time.RFC3339) + " " + itoa(b.Count) + "\n"
+		}
 	} else {
 		s += "No abnormal burst detected\n"
 	}
@@ -111,6 +292,9 @@ func formatDiversityClipboard(r *DiversityResult) string {
 	s := "ERROR DIVERSITY\n\n"
 	s += "Total ERROR lines:     " + itoa(r.TotalErrors) + "\n"
 	s += "Unique ERROR messages: " + itoa(r.UniqueErrors) + "\n"
+	if r.FatalCount > 0 {
+		s += "FATAL lines:           " + itoa(r.FatalCount) + "\n"
+	}
 	s += "\nSignal quality: " + r.Quality + "\n"
 	if r.QualityReason != "" {
 		s += r.QualityReason + "\n"
@@ -118,6 +302,48 @@ func formatDiversityClipboard(r *DiversityResult) string {
 	return s
 }
 
+func formatStatsClipboard(r *StatsResult) string {
+	if r == nil {
+		return ""
+	}
+	s := "NUMERIC STATS: " + r.Field + "\n\n"
+	if r.Count == 0 {
+		return s + "No numeric values found\n"
+	}
+	s += "count " + itoa(r.Count) + "  min " + FormatNumber(r.Min) + "  max " + FormatNumber(r.Max) + "  mean " + FormatNumber(r.Mean) + "\n"
+	s += "p50 " + FormatNumber(r.P50) + "  p90 " + FormatNumber(r.P90) + "  p99 " + FormatNumber(r.P99) + "\n"
+	if r.Skipped > 0 {
+		s += "skipped " + itoa(r.Skipped) + " non-numeric values\n"
+	}
+	s += "\nHISTOGRAM\n"
+	for _, b := range r.Histogram {
+		s += FormatNumber(b.Low) + " - " + FormatNumber(b.High) + ": " + itoa(b.Count) + "\n"
+	}
+	if r.GroupBy != "" {
+		s += "\nBY " + r.GroupBy + " (count p50 p90 p99 max)\n"
+		for _, g := range r.Groups {
+			s += g.Value + ": " + itoa(g.Count) + " " + FormatNumber(g.P50) + " " + FormatNumber(g.P90) + " " + FormatNumber(g.P99) + " " + FormatNumber(g.Max) + "\n"
+		}
+	}
+	return s
+}
+
+func formatRateClipboard(r *RateResult) string {
+	if r == nil {
+		return ""
+	}
+	s := "RATE TIMELINE: " + r.Label + "\n\n"
+	if len(r.Counts) == 0 {
+		return s + "No timestamped events\n"
+	}
+	s += "events " + itoa(r.Total) + ", interval " + FormatInterval(r.Interval) + "\n"
+	s += "peak " + itoa(r.Peak) + " at " + r.PeakStart().Format(time.RFC3339) + " (" + strconv.FormatFloat(r.PeakRatio(), 'f', 1, 64) + "x median " + FormatNumber(r.Median) + ")\n\n"
+	for i, n := range r.Counts {
+		s += r.Start.Add(time.Duration(i)*r.Interval).Format(time.RFC3339) + " " + itoa(n) + "\n"
+	}
+	return s
+}
+
 func itoa(n int) string {
 	if n == 0 {
 		return "0"
@@ -132,3 +358,75 @@ func itoa(n int) string {
 	}
 	return string(digits)
 }
+
+func (a Anomaly) Describe(reason AnomalyReason) string {
+	switch reason {
+	case AnomalyNew:
+		s := "new after " + itoa(int(a.Onset*100)) + "% of the log"
+		if !a.FirstAt.IsZero() {
+			s += " (" + a.FirstAt.Format("15:04:05") + ")"
+		}
+		return s
+	case AnomalySpike:
+		s := strconv.FormatFloat(a.SpikeRatio, 'f', 1, 64) + "x its usual rate"
+		if !a.SpikeAt.IsZero() {
+			s += " at " + a.SpikeAt.Format("15:04:05")
+		}
+		return s
+	case AnomalyEscalation:
+		return a.FromLevel.String() + " -> " + a.Level.String()
+	}
+	return ""
+}
+
+func formatAnomalyClipboard(r *AnomalyResult) string {
+	if r == nil {
+		return ""
+	}
+	s := "ANOMALIES\n\n"
+	s += itoa(r.Templates) + " templates in " + itoa(r.Total) + " lines\n\n"
+	if len(r.Items) == 0 {
+		return s + "No anomalies found\n"
+	}
+	for i, a := range r.Items {
+		s += itoa(i+1) + ". [" + strconv.FormatFloat(a.Score, 'f', 1, 64) + "] " + a.Template + " (" + itoa(a.Count) + "x)\n"
+		for _, reason := range a.Reasons {
+			s += "   " + a.Describe(reason) + "\n"
+		}
+	}
+	return s
+}
+
+func (s CompareStatus) String() string {
+	switch s {
+	case CompareNew:
+		return "NEW"
+	case CompareLevel:
+		return "LEVEL"
+	case CompareMore:
+		return "MORE"
+	case CompareLess:
+		return "LESS"
+	case CompareGone:
+		return "GONE"
+	}
+	return ""
+}
+
+func (r *CompareResult) FormatForClipboard() string {
+	if r == nil {
+		return ""
+	}
+	s := "BASELINE COMPARISON\n\n"
+	s += "Baseline: " + itoa(r.BaseTotal) + " lines, " + itoa(r.BaseTemplates) + " templates\n"
+	s += "Current:  " + itoa(r.CurrentTotal) + " lines, " + itoa(r.CurrentTemplates) + " templates\n"
+	s += "Unchanged: " + itoa(r.Unchanged) + "\n\n"
+	for _, item := range r.Items {
+		s += item.Status.String() + " " + item.Template + " (" + itoa(item.Base.Count) + " -> " + itoa(item.Current.Count)
+		if item.Status == CompareLevel {
+			s += ", " + item.Base.Level.String() + " -> " + item.Current.Level.String()
+		}
+		s += ")\n"
+	}
+	return s
+}
@@ -2,8 +2,10 @@ package ui
 
 import (
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 func SmallLogo() string {
@@ -72,14 +74,23 @@ func Frame(content string, width, height int) string {
 	return b.String()
 }
 
+const ansiReset = "\x1b[0m"
+
 func TruncateVisual(s string, maxW int) string {
 	if lipgloss.Width(s) <= maxW {
 		return s
 	}
-	for len(s) > 0 && lipgloss.Width(s) > maxW-3 {
-		s = s[:len(s)-1]
+	return ansi.Truncate(s, maxW, "...")
+}
+
+func SkipColumns(s string, n int) string {
+	for i, r := range s {
+		if n <= 0 {
+			return s[i:]
+		}
+		n -= ansi.StringWidth(string(r))
 	}
-	return s + "..."
+	return ""
 }
 
 func Truncate(s string, maxLen int) string {
@@ -180,6 +191,25 @@ func Itoa(n int) string {
 	return string(digits)
 }
 
+func FormatRuntime(d time.Duration) string {
+	secs := int(d.Seconds())
+	switch {
+	case secs < 60:
+		return Itoa(secs) + "s"
+	case secs < 3600:
+		return Itoa(secs/60) + "m" + twoDigits(secs%60) + "s"
+	default:
+		return Itoa(secs/3600) + "h" + twoDigits(secs/60%60) + "m"
+	}
+}
+
+func twoDigits(n int) string {
+	if n < 10 {
+		return "0" + Itoa(n)
+	}
+	return Itoa(n)
+}
+
 func Min(a, b int) int {
 	if a < b {
 		return a
@@ -1,6 +1,13 @@
 package ui
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
 
 const (
 	KeyUp           = "up"
@@ -45,6 +52,9 @@ const (
 	Key2            = "2"
 	Key3            = "3"
 	Key4            = "4"
+	Key5            = "5"
+	Key6            = "6"
+	Key7            = "7"
 	KeyPgUp         = "pgup"
 	KeyPgDn         = "pgdown"
 	KeyCtrlR        = "ctrl+r"
@@ -54,6 +64,22 @@ const (
 	KeyShiftW       = "W"
 	KeyU            = "u"
 	KeyShiftU       = "U"
+	KeyShiftA       = "A"
+	KeyCtrlE        = "ctrl+e"
+	KeyCtrlT        = "ctrl+t"
+	KeyE            = "e"
+	KeyShiftC       = "C"
+	KeyShiftB       = "B"
+	KeyShiftV       = "V"
+	KeyShiftR       = "R"
+	KeyH            = "h"
+	KeyL            = "l"
+	KeyShiftH       = "H"
+	KeyShiftL       = "L"
+	KeyW            = "w"
+	KeyT            = "t"
+	KeyF            = "f"
+	KeyExclaim      = "!"
 )
 
 func IsKey(msg tea.KeyMsg, keys ...string) bool {
@@ -65,6 +91,360 @@ func IsKey(msg tea.KeyMsg, keys ...string) bool {
 	return false
 }
 
+type Action string
+
+const (
+	ActionUp              Action = "up"
+	ActionDown            Action = "down"
+	ActionTop             Action = "top"
+	ActionBottom          Action = "bottom"
+	ActionPageUp          Action = "page_up"
+	ActionPageDown        Action = "page_down"
+	ActionScrollLeft      Action = "scroll_left"
+	ActionScrollRight     Action = "scroll_right"
+	ActionPageLeft        Action = "page_left"
+	ActionPageRight       Action = "page_right"
+	ActionWrap            Action = "wrap"
+	ActionDetail          Action = "detail"
+	ActionMaximize        Action = "maximize"
+	ActionFilter          Action = "filter"
+	ActionLevelCycle      Action = "level_cycle"
+	ActionLevelRangeEnd   Action = "level_range_end"
+	ActionLevelMode       Action = "level_mode"
+	ActionContinuation    Action = "continuation"
+	ActionExpandContext   Action = "expand_context"
+	ActionClearFilter     Action = "clear_filter"
+	ActionSelect          Action = "select"
+	ActionSelectAll       Action = "select_all"
+	ActionCopy            Action = "copy"
+	ActionCopyAll         Action = "copy_all"
+	ActionDelete          Action = "delete"
+	ActionClear           Action = "clear"
+	ActionUndo            Action = "undo"
+	ActionRedo            Action = "redo"
+	ActionPaste           Action = "paste"
+	ActionOpen            Action = "open"
+	ActionANSI            Action = "ansi"
+	ActionColumns         Action = "columns"
+	ActionFields          Action = "fields"
+	ActionRestart         Action = "restart"
+	ActionNoteEdit        Action = "note_edit"
+	ActionNoteDelete      Action = "note_delete"
+	ActionNoteToggle      Action = "note_toggle"
+	ActionNotesAll        Action = "notes_all"
+	ActionNoteNext        Action = "note_next"
+	ActionNotePrev        Action = "note_prev"
+	ActionWorkspaceNew    Action = "workspace_new"
+	ActionWorkspaceNext   Action = "workspace_next"
+	ActionWorkspacePrev   Action = "workspace_prev"
+	ActionWorkspaceClose  Action = "workspace_close"
+	ActionSignalFrequency Action = "signal_frequency"
+	ActionSignalLifetime  Action = "signal_lifetime"
+	ActionSignalBurst     Action = "signal_burst"
+	ActionSignalDiversity Action = "signal_diversity"
+	ActionSignalStats     Action = "signal_stats"
+	ActionSignalRate      Action = "signal_rate"
+	ActionSignalAnomalies Action = "signal_anomalies"
+	ActionCorrelate       Action = "correlate"
+	ActionCompare         Action = "compare"
+	ActionDiff            Action = "diff"
+	ActionLookup          Action = "lookup"
+	ActionPanelNext       Action = "panel_next"
+	ActionPanelPrev       Action = "panel_prev"
+	ActionPanelOpen       Action = "panel_open"
+	ActionHelp            Action = "help"
+	ActionQuit            Action = "quit"
+)
+
+type Keymap map[Action][]string
+
+type actionScope int
+
+const (
+	scopeList actionScope = iota
+	scopeFilter
+	scopePanel
+)
+
+var actionScopes = map[Action]actionScope{
+	ActionLevelCycle:    scopeFilter,
+	ActionLevelRangeEnd: scopeFilter,
+	ActionLevelMode:     scopeFilter,
+	ActionContinuation:  scopeFilter,
+	ActionPanelNext:     scopePanel,
+	ActionPanelPrev:     scopePanel,
+	ActionPanelOpen:     scopePanel,
+}
+
+func DefaultKeymap() Keymap {
+	return Keymap{
+		ActionUp:              {KeyK, KeyUp},
+		ActionDown:            {KeyJ, KeyDown},
+		ActionTop:             {KeyG},
+		ActionBottom:          {KeyShiftG},
+		ActionPageUp:          {KeyPgUp},
+		ActionPageDown:        {KeyPgDn},
+		ActionScrollLeft:      {KeyH, KeyLeft},
+		ActionScrollRight:     {KeyL, KeyRight},
+		ActionPageLeft:        {KeyShiftH},
+		ActionPageRight:       {KeyShiftL},
+		ActionWrap:            {KeyW},
+		ActionDetail:          {KeyEnter, KeySpace},
+		ActionMaximize:        {KeyZ},
+		ActionFilter:          {KeySlash},
+		ActionLevelCycle:      {KeyTab},
+		ActionLevelRangeEnd:   {KeyShiftTab},
+		ActionLevelMode:       {KeyCtrlE},
+		ActionContinuation:    {KeyCtrlT},
+		ActionExpandContext:   {KeyE},
+		ActionClearFilter:     {KeyCtrlR},
+		ActionSelect:          {KeyS},
+		ActionSelectAll:       {KeyShiftS},
+		ActionCopy:            {KeyC},
+		ActionCopyAll:         {KeyY},
+		ActionDelete:          {KeyD},
+		ActionClear:           {KeyX},
+		ActionUndo:            {KeyU},
+		ActionRedo:            {KeyShiftU},
+		ActionPaste:           {KeyP, KeyCtrlV},
+		ActionOpen:            {KeyO},
+		ActionANSI:            {KeyShiftA},
+		ActionColumns:         {KeyT},
+		ActionFields:          {KeyF},
+		ActionRestart:         {KeyShiftR},
+		ActionNoteEdit:        {KeyShiftN},
+		ActionNoteDelete:      {KeyShiftD},
+		ActionNoteToggle:      {KeyN},
+		ActionNotesAll:        {KeyM},
+		ActionNoteNext:        {KeyBracketRight, KeyU_TR},
+		ActionNotePrev:        {KeyBracketLeft, KeyG_TR},
+		ActionWorkspaceNew:    {KeyShiftT},
+		ActionWorkspaceNext:   {KeyTab},
+		ActionWorkspacePrev:   {KeyShiftTab},
+		ActionWorkspaceClose:  {KeyShiftW},
+		ActionSignalFrequency: {Key1},
+		ActionSignalLifetime:  {Key2},
+		ActionSignalBurst:     {Key3},
+		ActionSignalDiversity: {Key4},
+		ActionSignalStats:     {Key5},
+		ActionSignalRate:      {Key6},
+		ActionSignalAnomalies: {Key7},
+		ActionCorrelate:       {KeyShiftC},
+		ActionCompare:         {KeyShiftB},
+		ActionDiff:            {KeyShiftV},
+		ActionLookup:          {KeyCtrlL},
+		ActionPanelNext:       {KeyTab},
+		ActionPanelPrev:       {KeyShiftTab},
+		ActionPanelOpen:       {KeyEnter},
+		ActionHelp:            {KeyQuestion},
+		ActionQuit:            {KeyQ},
+	}
+}
+
+var keymapPresets = map[string]Keymap{
+	"default": {},
+	"vim": {
+		ActionTop:      {KeyG, KeyHome},
+		ActionBottom:   {KeyShiftG, KeyEnd},
+		ActionPageDown: {"ctrl+d", "ctrl+f", KeyPgDn},
+		ActionPageUp:   {"ctrl+u", "ctrl+b", KeyPgUp},
+	},
+	"emacs": {
+		ActionDown:        {"ctrl+n", KeyDown},
+		ActionUp:          {"ctrl+p", KeyUp},
+		ActionTop:         {"alt+<", KeyHome},
+		ActionBottom:      {"alt+>", KeyEnd},
+		ActionPageDown:    {KeyCtrlV, KeyPgDn},
+		ActionPageUp:      {"alt+v", KeyPgUp},
+		ActionFilter:      {"ctrl+s", KeySlash},
+		ActionClearFilter: {"ctrl+g", KeyCtrlR},
+		ActionPaste:       {"ctrl+y", KeyP},
+		ActionScrollLeft:  {"ctrl+b", KeyLeft},
+		ActionScrollRight: {"ctrl+f", KeyRight},
+	},
+}
+
+var activeKeymap = DefaultKeymap()
+
+func KeymapPresets() []string {
+	names := make([]string, 0, len(keymapPresets))
+	for name := range keymapPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func NewKeymap(preset string, bindings map[string][]string) (Keymap, error) {
+	if preset == "" {
+		preset = "default"
+	}
+	overrides, ok := keymapPresets[preset]
+	if !ok {
+		return nil, errors.New("keys.preset: unknown preset " + strconv.Quote(preset) + " (want " + strings.Join(KeymapPresets(), ", ") + ")")
+	}
+
+	km := DefaultKeymap()
+	for action, keys := range overrides {
+		km.Bind(action, keys)
+	}
+
+	names := make([]string, 0, len(bindings))
+	for name := range bindings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	takenBy := make(map[Action]string)
+	for _, name := range names {
+		action := Action(name)
+		if _, ok := km[action]; !ok {
+			return nil, errors.New("keys." + name + ": unknown action")
+		}
+		if len(bindings[name]) == 0 {
+			return nil, errors.New("keys." + name + ": no keys given")
+		}
+		for _, emptied := range km.Bind(action, bindings[name]) {
+			takenBy[emptied] = name
+		}
+	}
+	for _, name := range names {
+		var lost []string
+		for action, by := range takenBy {
+			if by == name && len(km[action]) == 0 {
+				lost = append(lost, string(action))
+			}
+		}
+		if len(lost) > 0 {
+			sort.Strings(lost)
+			return nil, errors.New("keys." + name + ": takes the last key of " + lost[0] + " (bind " + lost[0] + " to another key)")
+		}
+	}
+	return km, nil
+}
+
+func ApplyKeymap(preset string, bindings map[string][]string) error {
+	km, err := NewKeymap(preset, bindings)
+	if err != nil {
+		return err
+	}
+	activeKeymap = km
+	return nil
+}
+
+func (km Keymap) Bind(action Action, keys []string) []Action {
+	var emptied []Action
+	for other, bound := range km {
+		if other == action || actionScopes[other] != actionScopes[action] {
+			continue
+		}
+		kept := bound[:0:0]
+		for _, k := range bound {
+			if !containsKey(keys, k) {
+				kept = append(kept, k)
+			}
+		}
+		if len(kept) == 0 && len(bound) > 0 {
+			emptied = append(emptied, other)
+		}
+		km[other] = kept
+	}
+	km[action] = append([]string(nil), keys...)
+	return emptied
+}
+
+func containsKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func IsAction(msg tea.KeyMsg, actions ...Action) bool {
+	for _, action := range actions {
+		if IsKey(msg, activeKeymap[action]...) {
+			return true
+		}
+	}
+	return false
+}
+
+func KeyLabel(actions ...Action) string {
+	return keyLabel(false, actions...)
+}
+
+func ShortKeyLabel(actions ...Action) string {
+	return keyLabel(true, actions...)
+}
+
+func keyLabel(short bool, actions ...Action) string {
+	var parts []string
+	if len(actions) == 1 {
+		keys := activeKeymap[actions[0]]
+		if len(keys) > 2 {
+			keys = keys[:2]
+		}
+		for _, k := range keys {
+			parts = append(parts, keyName(k, short))
+		}
+	} else {
+		for _, action := range actions {
+			if keys := activeKeymap[action]; len(keys) > 0 {
+				parts = append(parts, keyName(keys[0], short))
+			}
+		}
+	}
+	if len(parts) == 0 {
+		return "-"
+	}
+	sep := "/"
+	if containsKey(parts, "/") {
+		sep = " "
+	}
+	return strings.Join(parts, sep)
+}
+
+var keyNames = map[string][2]string{
+	KeyUp:        {"↑", "↑"},
+	KeyDown:      {"↓", "↓"},
+	KeyLeft:      {"←", "←"},
+	KeyRight:     {"→", "→"},
+	KeyEnter:     {"Enter", "Enter"},
+	KeySpace:     {"Space", "␣"},
+	KeyEsc:       {"ESC", "ESC"},
+	KeyTab:       {"Tab", "Tab"},
+	KeyShiftTab:  {"Shift+Tab", "S-Tab"},
+	KeyPgUp:      {"PgUp", "PgUp"},
+	KeyPgDn:      {"PgDn", "PgDn"},
+	KeyHome:      {"Home", "Home"},
+	KeyEnd:       {"End", "End"},
+	KeyBackspace: {"Backspace", "BS"},
+	KeyDelete:    {"Delete", "Del"},
+}
+
+func keyName(k string, short bool) string {
+	if names, ok := keyNames[k]; ok {
+		if short {
+			return names[1]
+		}
+		return names[0]
+	}
+	if rest, ok := strings.CutPrefix(k, "ctrl+"); ok {
+		if short {
+			return "^" + strings.ToUpper(rest)
+		}
+		return "Ctrl+" + strings.ToUpper(rest)
+	}
+	if rest, ok := strings.CutPrefix(k, "alt+"); ok {
+		if short {
+			return "M-" + rest
+		}
+		return "Alt+" + rest
+	}
+	return k
+}
+
 type HelpSection struct {
 	Title string
 	Items []HelpItem
@@ -80,76 +460,96 @@ func GetHelpSections() []HelpSection {
 		{
 			Title: "Navigation",
 			Items: []HelpItem{
-				{"j/k", "Move down/up"},
-				{"g/G", "Jump to top/bottom"},
-				{"Enter", "Toggle detail view"},
-				{"z", "Maximize/minimize detail"},
+				{KeyLabel(ActionDown, ActionUp), "Move down/up"},
+				{KeyLabel(ActionTop, ActionBottom), "Jump to top/bottom"},
+				{KeyLabel(ActionPageUp, ActionPageDown), "Scroll detail"},
+				{KeyLabel(ActionScrollLeft, ActionScrollRight), "Scroll lines left/right"},
+				{KeyLabel(ActionPageLeft, ActionPageRight), "Scroll a page left/right"},
+				{KeyLabel(ActionWrap), "Toggle line wrap"},
+				{KeyLabel(ActionDetail), "Toggle detail view"},
+				{KeyLabel(ActionMaximize), "Maximize/minimize detail"},
 			},
 		},
 		{
 			Title: "Filter",
 			Items: []HelpItem{
-				{"/", "Start filter"},
-				{"Tab", "Cycle level filter"},
-				{"Ctrl+R", "Clear filter"},
+				{KeyLabel(ActionFilter), "Start filter"},
+				{KeyLabel(ActionLevelCycle), "Cycle level filter"},
+				{KeyLabel(ActionLevelMode), "Level mode (≥/exact/range)"},
+				{KeyLabel(ActionLevelRangeEnd), "Cycle range end"},
+				{KeyLabel(ActionContinuation), "Include continuation lines"},
+				{KeyLabel(ActionExpandContext), "Expand context around line"},
+				{KeyLabel(ActionFields), "Field explorer (add field=value)"},
+				{KeyLabel(ActionClearFilter), "Clear filter"},
 				{"ESC", "Exit filter mode"},
 			},
 		},
 		{
 			Title: "Selection",
 			Items: []HelpItem{
-				{"s", "Toggle selection"},
-				{"S", "Select all/clear"},
-				{"c", "Copy selected"},
-				{"d", "Delete selected"},
+				{KeyLabel(ActionSelect), "Toggle selection"},
+				{KeyLabel(ActionSelectAll), "Select all/clear"},
+				{KeyLabel(ActionCopy), "Copy selected"},
+				{KeyLabel(ActionDelete), "Delete selected"},
 			},
 		},
 		{
 			Title: "Actions",
 			Items: []HelpItem{
-				{"y", "Copy visible logs + notes"},
-				{"c", "Copy current line"},
-				{"d", "Delete current"},
-				{"u/U", "Undo/redo delete"},
-				{"x", "Clear all"},
-				{"p", "Paste from clipboard"},
-				{"o", "Open file"},
+				{KeyLabel(ActionCopyAll), "Copy visible logs + notes"},
+				{KeyLabel(ActionCopy), "Copy current line"},
+				{KeyLabel(ActionDelete), "Delete current"},
+				{KeyLabel(ActionUndo, ActionRedo), "Undo/redo delete"},
+				{KeyLabel(ActionClear), "Clear all"},
+				{KeyLabel(ActionPaste), "Paste from clipboard"},
+				{KeyLabel(ActionOpen), "Open file"},
+				{KeyLabel(ActionANSI), "Toggle original ANSI colors"},
+				{KeyLabel(ActionColumns), "Pick table columns"},
+				{KeyLabel(ActionRestart), "Restart command (lx -- cmd)"},
 			},
 		},
 		{
 			Title: "Notes",
 			Items: []HelpItem{
-				{"N", "Write/edit note"},
-				{"D", "Delete note"},
-				{"n", "Show/hide note"},
-				{"m", "Show/hide all notes"},
-				{"]/[", "Next/prev noted line"},
+				{KeyLabel(ActionNoteEdit), "Write/edit note"},
+				{KeyLabel(ActionNoteDelete), "Delete note"},
+				{KeyLabel(ActionNoteToggle), "Show/hide note"},
+				{KeyLabel(ActionNotesAll), "Show/hide all notes"},
+				{KeyLabel(ActionNoteNext, ActionNotePrev), "Next/prev noted line"},
 			},
 		},
 		{
 			Title: "Workspace",
 			Items: []HelpItem{
-				{"T", "New workspace"},
-				{"Tab", "Next workspace"},
-				{"Shift+Tab", "Prev workspace"},
-				{"W", "Close workspace"},
+				{KeyLabel(ActionWorkspaceNew), "New workspace"},
+				{KeyLabel(ActionWorkspaceNext), "Next workspace"},
+				{KeyLabel(ActionWorkspacePrev), "Prev workspace"},
+				{KeyLabel(ActionWorkspaceClose), "Close workspace"},
 			},
 		},
 		{
 			Title: "Signal",
 			Items: []HelpItem{
-				{"1", "Error frequency"},
-				{"2", "First/last seen"},
-				{"3", "Burst detector"},
-				{"4", "Error diversity"},
+				{KeyLabel(ActionSignalFrequency), "Error frequency"},
+				{KeyLabel(ActionSignalLifetime), "First/last seen"},
+				{KeyLabel(ActionSignalBurst), "Burst detector"},
+				{KeyLabel(ActionSignalDiversity), "Error diversity"},
+				{KeyLabel(ActionSignalStats), "Numeric field stats"},
+				{KeyLabel(ActionSignalRate), "Rate timeline"},
+				{KeyLabel(ActionSignalAnomalies), "Anomalies across all messages"},
+				{KeyLabel(ActionCorrelate), "Correlate trace/request ID"},
+				{KeyLabel(ActionPanelNext, ActionPanelPrev), "Switch view in a panel"},
+				{KeyLabel(ActionPanelOpen), "Jump or filter from a panel"},
 			},
 		},
 		{
 			Title: "Tools",
 			Items: []HelpItem{
-				{"Ctrl+L", "HTTP status lookup"},
-				{"?", "Toggle help"},
-				{"q", "Quit"},
+				{KeyLabel(ActionLookup), "HTTP status lookup"},
+				{KeyLabel(ActionCompare), "Compare with baseline workspace"},
+				{KeyLabel(ActionDiff), "Line diff against baseline workspace"},
+				{KeyLabel(ActionHelp), "Toggle help"},
+				{KeyLabel(ActionQuit), "Quit"},
 			},
 		},
 	}
@@ -168,19 +568,19 @@ func FooterHints(mode int) string {
 
 	switch mode {
 	case modeList:
-		return "j/k:nav  /:filter  Enter:detail  ?:help  q:quit"
+		return KeyLabel(ActionDown, ActionUp) + ":nav  " + KeyLabel(ActionFilter) + ":filter  " + KeyLabel(ActionDetail) + ":detail  " + KeyLabel(ActionHelp) + ":help  " + KeyLabel(ActionQuit) + ":quit"
 	case modeFilter:
 		return "Type to filter  ESC:cancel  Enter:apply"
 	case modeDetail:
-		return "ESC:back  j/k:nav  c:copy  ?:help"
+		return "ESC:back  " + KeyLabel(ActionDown, ActionUp) + ":nav  " + KeyLabel(ActionCopy) + ":copy  " + KeyLabel(ActionHelp) + ":help"
 	case modeHelp:
-		return "ESC/?:close"
+		return "ESC/" + KeyLabel(ActionHelp) + ":close"
 	case modeNotes:
 		return "Type notes  ESC:close  Ctrl+C:copy notes"
 	case modeLookup:
 		return "Type code  j/k:nav  Enter:copy  ESC:close"
 	case modeSignal:
-		return "c:copy  ESC:close"
+		return KeyLabel(ActionCopy) + ":copy  ESC:close"
 	default:
 		return ""
 	}
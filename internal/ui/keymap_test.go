@@ -0,0 +1,205 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func keyMsg(k string) tea.KeyMsg {
+	switch k {
+	case KeyTab:
+		return tea.KeyMsg{Type: tea.KeyTab}
+	case KeyShiftTab:
+		return tea.KeyMsg{Type: tea.KeyShiftTab}
+	case KeyEnter:
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case KeyCtrlE:
+		return tea.KeyMsg{Type: tea.KeyCtrlE}
+	case "ctrl+o":
+		return tea.KeyMsg{Type: tea.KeyCtrlO}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+}
+
+func withKeymap(t *testing.T, preset string, bindings map[string][]string) {
+	t.Helper()
+	saved := activeKeymap
+	t.Cleanup(func() { activeKeymap = saved })
+	if err := ApplyKeymap(preset, bindings); err != nil {
+		t.Fatalf("ApplyKeymap(%q, %v) error = %v", preset, bindings, err)
+	}
+}
+
+func TestDefaultKeymapConflicts(t *testing.T) {
+	owner := make(map[actionScope]map[string]Action)
+	for action, keys := range DefaultKeymap() {
+		if len(keys) == 0 {
+			t.Errorf("%s has no keys", action)
+		}
+		scope := actionScopes[action]
+		if owner[scope] == nil {
+			owner[scope] = make(map[string]Action)
+		}
+		for _, k := range keys {
+			if other, ok := owner[scope][k]; ok {
+				t.Errorf("%q is bound to both %s and %s", k, other, action)
+			}
+			owner[scope][k] = action
+		}
+	}
+}
+
+func TestPresetsKeepEveryAction(t *testing.T) {
+	for _, preset := range KeymapPresets() {
+		km, err := NewKeymap(preset, nil)
+		if err != nil {
+			t.Fatalf("NewKeymap(%q) error = %v", preset, err)
+		}
+		for action, keys := range km {
+			if len(keys) == 0 {
+				t.Errorf("%s preset leaves %s without keys", preset, action)
+			}
+		}
+	}
+}
+
+func TestNewKeymap(t *testing.T) {
+	tests := []struct {
+		name     string
+		preset   string
+		bindings map[string][]string
+		want     map[Action][]string
+	}{
+		{
+			name: "default",
+			want: map[Action][]string{ActionTop: {KeyG}, ActionFilter: {KeySlash}},
+		},
+		{
+			name:   "vim preset",
+			preset: "vim",
+			want:   map[Action][]string{ActionTop: {KeyG, KeyHome}, ActionPageDown: {"ctrl+d", "ctrl+f", KeyPgDn}},
+		},
+		{
+			name:   "emacs preset moves ctrl+v from paste to page down",
+			preset: "emacs",
+			want:   map[Action][]string{ActionPageDown: {KeyCtrlV, KeyPgDn}, ActionPaste: {"ctrl+y", KeyP}},
+		},
+		{
+			name:     "binding replaces the action's keys",
+			bindings: map[string][]string{"workspace_next": {"ctrl+o"}},
+			want:     map[Action][]string{ActionWorkspaceNext: {"ctrl+o"}, ActionPanelNext: {KeyTab}, ActionLevelCycle: {KeyTab}},
+		},
+		{
+			name:     "binding takes the key from another list action",
+			bindings: map[string][]string{"copy": {KeyY}, "copy_all": {KeyC}},
+			want:     map[Action][]string{ActionCopy: {KeyY}, ActionCopyAll: {KeyC}},
+		},
+		{
+			name:     "modal bindings leave list keys alone",
+			bindings: map[string][]string{"level_mode": {KeyCtrlL}, "panel_open": {KeyO}},
+			want:     map[Action][]string{ActionLevelMode: {KeyCtrlL}, ActionLookup: {KeyCtrlL}, ActionPanelOpen: {KeyO}, ActionOpen: {KeyO}},
+		},
+		{
+			name:     "list bindings leave modal keys alone",
+			bindings: map[string][]string{"detail": {KeyEnter}, "workspace_prev": {KeyShiftTab}},
+			want:     map[Action][]string{ActionDetail: {KeyEnter}, ActionPanelOpen: {KeyEnter}, ActionLevelRangeEnd: {KeyShiftTab}},
+		},
+		{
+			name:     "modal bindings conflict within their scope",
+			bindings: map[string][]string{"panel_next": {KeyTab, KeyN}, "panel_prev": {KeyTab, KeyShiftTab}},
+			want:     map[Action][]string{ActionPanelNext: {KeyN}, ActionPanelPrev: {KeyTab, KeyShiftTab}, ActionLevelCycle: {KeyTab}},
+		},
+		{
+			name:     "an action keeps its other keys",
+			bindings: map[string][]string{"top": {KeyHome}},
+			preset:   "vim",
+			want:     map[Action][]string{ActionTop: {KeyHome}},
+		},
+	}
+
+	for _, tt := range tests {
+		km, err := NewKeymap(tt.preset, tt.bindings)
+		if err != nil {
+			t.Fatalf("%s: NewKeymap error = %v", tt.name, err)
+		}
+		for action, want := range tt.want {
+			if got := km[action]; !reflect.DeepEqual(got, want) && (len(got) > 0 || len(want) > 0) {
+				t.Errorf("%s: %s = %v, want %v", tt.name, action, got, want)
+			}
+		}
+	}
+}
+
+func TestNewKeymapErrors(t *testing.T) {
+	tests := []struct {
+		preset   string
+		bindings map[string][]string
+		want     string
+	}{
+		{"helix", nil, `keys.preset: unknown preset "helix"`},
+		{"", map[string][]string{"jump": {"j"}}, "keys.jump: unknown action"},
+		{"", map[string][]string{"quit": {}}, "keys.quit: no keys given"},
+		{"", map[string][]string{"copy": {KeyY}}, "keys.copy: takes the last key of copy_all"},
+		{"", map[string][]string{"panel_prev": {KeyTab}}, "keys.panel_prev: takes the last key of panel_next"},
+		{"", map[string][]string{"copy_all": {KeyC}}, "keys.copy_all: takes the last key of copy"},
+	}
+
+	for _, tt := range tests {
+		_, err := NewKeymap(tt.preset, tt.bindings)
+		if err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("NewKeymap(%q, %v) error = %v, want %q", tt.preset, tt.bindings, err, tt.want)
+		}
+	}
+}
+
+func TestIsAction(t *testing.T) {
+	withKeymap(t, "", map[string][]string{"level_cycle": {"ctrl+o"}, "panel_next": {KeyL}})
+
+	tests := []struct {
+		key    string
+		action Action
+		want   bool
+	}{
+		{"ctrl+o", ActionLevelCycle, true},
+		{KeyTab, ActionLevelCycle, false},
+		{KeyShiftTab, ActionLevelRangeEnd, true},
+		{KeyCtrlE, ActionLevelMode, true},
+		{KeyL, ActionPanelNext, true},
+		{KeyL, ActionScrollRight, true},
+		{KeyTab, ActionPanelNext, false},
+		{KeyTab, ActionWorkspaceNext, true},
+		{KeyEnter, ActionPanelOpen, true},
+		{KeyJ, ActionDown, true},
+	}
+
+	for _, tt := range tests {
+		if got := IsAction(keyMsg(tt.key), tt.action); got != tt.want {
+			t.Errorf("IsAction(%q, %s) = %v, want %v", tt.key, tt.action, got, tt.want)
+		}
+	}
+}
+
+func TestKeyLabel(t *testing.T) {
+	withKeymap(t, "emacs", map[string][]string{"level_mode": {"ctrl+x"}, "panel_prev": {"alt+p"}})
+
+	tests := []struct {
+		got, want string
+	}{
+		{KeyLabel(ActionLevelMode), "Ctrl+X"},
+		{ShortKeyLabel(ActionLevelMode), "^X"},
+		{ShortKeyLabel(ActionPanelPrev), "M-p"},
+		{KeyLabel(ActionPanelNext, ActionPanelPrev), "Tab/Alt+p"},
+		{KeyLabel(ActionFilter), "Ctrl+S /"},
+		{KeyLabel(ActionDown, ActionUp), "Ctrl+N/Ctrl+P"},
+		{ShortKeyLabel(ActionTop), "M-</Home"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("label = %q, want %q", tt.got, tt.want)
+		}
+	}
+}
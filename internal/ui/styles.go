@@ -6,256 +6,404 @@ import (
 )
 
 var (
-	ColorAccent = lipgloss.Color("#FF6B00")
-
-	ColorError   = lipgloss.Color("#E54B4B")
-	ColorWarn    = lipgloss.Color("#E5C07B")
-	ColorInfo    = lipgloss.Color("#5B8FB9")
-	ColorDebug   = lipgloss.Color("#7B68A6")
-	ColorTrace   = lipgloss.Color("#5B9B8B")
-	ColorSuccess = lipgloss.Color("#6B9B6B")
-
-	ColorBg       = lipgloss.Color("#0C0C10")
-	ColorBgAlt    = lipgloss.Color("#121218")
-	ColorBgPanel  = lipgloss.Color("#2A2A38")
-	ColorBgSelect = lipgloss.Color("#4A2800")
+	ColorAccent lipgloss.TerminalColor
+
+	ColorFatal    lipgloss.TerminalColor
+	ColorAlert    lipgloss.TerminalColor
+	ColorCritical lipgloss.TerminalColor
+	ColorError    lipgloss.TerminalColor
+	ColorWarn     lipgloss.TerminalColor
+	ColorNotice   lipgloss.TerminalColor
+	ColorInfo     lipgloss.TerminalColor
+	ColorDebug    lipgloss.TerminalColor
+	ColorTrace    lipgloss.TerminalColor
+	ColorSuccess  lipgloss.TerminalColor
+
+	ColorBg       lipgloss.TerminalColor
+	ColorBgAlt    lipgloss.TerminalColor
+	ColorBgPanel  lipgloss.TerminalColor
+	ColorBgSelect lipgloss.TerminalColor
+
+	ColorTextPrimary   lipgloss.TerminalColor
+	ColorTextSecondary lipgloss.TerminalColor
+	ColorTextMuted     lipgloss.TerminalColor
+	ColorTextBright    lipgloss.TerminalColor
+
+	LaneColors []lipgloss.TerminalColor
+
+	ColorBorder  lipgloss.TerminalColor
+	ColorDivider lipgloss.TerminalColor
+	ColorNoteBox lipgloss.TerminalColor
+)
 
-	ColorTextPrimary   = lipgloss.Color("#D0D0DC")
-	ColorTextSecondary = lipgloss.Color("#8888A0")
-	ColorTextMuted     = lipgloss.Color("#505068")
-	ColorTextBright    = lipgloss.Color("#EEEEF8")
+var (
+	StyleLogo            lipgloss.Style
+	StyleLogoText        lipgloss.Style
+	StyleBar             lipgloss.Style
+	StyleBarText         lipgloss.Style
+	StyleBarAccent       lipgloss.Style
+	StyleBarHighlight    lipgloss.Style
+	StyleBarSuccess      lipgloss.Style
+	StyleBarError        lipgloss.Style
+	StyleBarDim          lipgloss.Style
+	StyleDivider         lipgloss.Style
+	StyleLineNum         lipgloss.Style
+	StyleLineNumSelected lipgloss.Style
+	StyleCursorIndicator lipgloss.Style
+	StyleSelectedLine    lipgloss.Style
+	StyleTimestamp       lipgloss.Style
+	StyleMessage         lipgloss.Style
+	StyleFatalMessage    lipgloss.Style
+	StyleStderrMarker    lipgloss.Style
+	StyleContextMessage  lipgloss.Style
+	StyleHunkSeparator   lipgloss.Style
+	StyleDiffAdded       lipgloss.Style
+	StyleDiffRemoved     lipgloss.Style
+	StyleDiffChanged     lipgloss.Style
+	StyleStack           lipgloss.Style
+	StyleLevelFatal      lipgloss.Style
+	StyleLevelAlert      lipgloss.Style
+	StyleLevelCritical   lipgloss.Style
+	StyleLevelError      lipgloss.Style
+	StyleLevelWarn       lipgloss.Style
+	StyleLevelNotice     lipgloss.Style
+	StyleLevelInfo       lipgloss.Style
+	StyleLevelDebug      lipgloss.Style
+	StyleLevelTrace      lipgloss.Style
+	StyleLevelUnknown    lipgloss.Style
+	StyleFilter          lipgloss.Style
+	StyleFilterInput     lipgloss.Style
+	StyleFilterActive    lipgloss.Style
+	StyleStatus          lipgloss.Style
+	StyleEmpty           lipgloss.Style
+	StyleEmptyBox        lipgloss.Style
+	StyleDetailHeader    lipgloss.Style
+	StyleDetailLabel     lipgloss.Style
+	StyleDetailValue     lipgloss.Style
+	StyleDetailDim       lipgloss.Style
+	StyleJSONKey         lipgloss.Style
+	StyleJSONString      lipgloss.Style
+	StyleJSONNumber      lipgloss.Style
+	StyleJSONBool        lipgloss.Style
+	StyleJSONNull        lipgloss.Style
+	StyleHelp            lipgloss.Style
+	StyleHelpSection     lipgloss.Style
+	StyleHelpKey         lipgloss.Style
+	StyleHelpDesc        lipgloss.Style
+	StyleFooter          lipgloss.Style
+	StyleCredit          lipgloss.Style
+	StyleNotesInput      lipgloss.Style
+	StyleNotesHeader     lipgloss.Style
+	StyleNoteIndicator   lipgloss.Style
+	StyleNoteBox         lipgloss.Style
+	StyleNoteBoxBorder   lipgloss.Style
+	StyleLookupInput     lipgloss.Style
+	StyleLookupResult    lipgloss.Style
+	StyleLookupSelected  lipgloss.Style
+	StyleFrameBorder     lipgloss.Style
+	StyleModalInner      lipgloss.Style
+	StyleModalText       lipgloss.Style
+	StyleModalHighlight  lipgloss.Style
+	StyleModalDim        lipgloss.Style
+	StyleModalAccent     lipgloss.Style
+)
 
-	ColorBorder  = lipgloss.Color("#282838")
-	ColorDivider = lipgloss.Color("#303040")
-	ColorNoteBox = lipgloss.Color("#3D3D50")
+func init() {
+	ApplyTheme(DefaultTheme, nil)
+}
 
+func buildStyles() {
 	StyleLogo = lipgloss.NewStyle().
-			Foreground(ColorAccent).
-			Bold(true)
+		Foreground(ColorAccent).
+		Bold(true)
 
 	StyleLogoText = lipgloss.NewStyle().
-			Foreground(ColorTextPrimary).
-			Bold(true)
+		Foreground(ColorTextPrimary).
+		Bold(true)
 
 	StyleBar = lipgloss.NewStyle().
-			Background(ColorBgPanel).
-			Foreground(ColorTextPrimary)
+		Background(ColorBgPanel).
+		Foreground(ColorTextPrimary)
 
 	StyleBarText = lipgloss.NewStyle().
-			Background(ColorBgPanel).
-			Foreground(ColorTextSecondary)
+		Background(ColorBgPanel).
+		Foreground(ColorTextSecondary)
 
 	StyleBarAccent = lipgloss.NewStyle().
-			Background(ColorBgPanel).
-			Foreground(ColorAccent).
-			Bold(true)
+		Background(ColorBgPanel).
+		Foreground(ColorAccent).
+		Bold(true)
 
 	StyleBarHighlight = lipgloss.NewStyle().
-				Background(ColorBgPanel).
-				Foreground(ColorTextBright).
-				Bold(true)
+		Background(ColorBgPanel).
+		Foreground(ColorTextBright).
+		Bold(true)
+
+	StyleBarSuccess = lipgloss.NewStyle().
+		Background(ColorBgPanel).
+		Foreground(ColorSuccess).
+		Bold(true)
+
+	StyleBarError = lipgloss.NewStyle().
+		Background(ColorBgPanel).
+		Foreground(ColorError).
+		Bold(true)
 
 	StyleBarDim = lipgloss.NewStyle().
-			Background(ColorBgPanel).
-			Foreground(ColorTextMuted)
+		Background(ColorBgPanel).
+		Foreground(ColorTextMuted)
 
 	StyleDivider = lipgloss.NewStyle().
-			Foreground(ColorDivider)
+		Foreground(ColorDivider)
 
 	StyleLineNum = lipgloss.NewStyle().
-			Foreground(ColorTextMuted)
+		Foreground(ColorTextMuted)
 
 	StyleLineNumSelected = lipgloss.NewStyle().
-				Foreground(ColorAccent).
-				Bold(true)
+		Foreground(ColorAccent).
+		Bold(true)
 
 	StyleCursorIndicator = lipgloss.NewStyle().
-				Foreground(ColorAccent).
-				Bold(true)
+		Foreground(ColorAccent).
+		Bold(true)
 
 	StyleSelectedLine = lipgloss.NewStyle().
-				Background(ColorBgSelect).
-				Foreground(ColorTextBright)
+		Background(ColorBgSelect).
+		Foreground(ColorTextBright)
 
 	StyleTimestamp = lipgloss.NewStyle().
-			Foreground(ColorAccent)
+		Foreground(ColorAccent)
 
 	StyleMessage = lipgloss.NewStyle().
-			Foreground(ColorTextPrimary)
+		Foreground(ColorTextPrimary)
+
+	StyleFatalMessage = lipgloss.NewStyle().
+		Foreground(ColorFatal).
+		Bold(true)
+
+	StyleStderrMarker = lipgloss.NewStyle().
+		Foreground(ColorError)
+
+	StyleContextMessage = lipgloss.NewStyle().
+		Foreground(ColorTextMuted)
+
+	StyleHunkSeparator = lipgloss.NewStyle().
+		Foreground(ColorDivider)
+
+	StyleDiffAdded = lipgloss.NewStyle().
+		Foreground(ColorSuccess)
+
+	StyleDiffRemoved = lipgloss.NewStyle().
+		Foreground(ColorError)
+
+	StyleDiffChanged = lipgloss.NewStyle().
+		Foreground(ColorWarn)
 
 	StyleStack = lipgloss.NewStyle().
-			Foreground(ColorTextSecondary).
-			Italic(true)
+		Foreground(ColorTextSecondary).
+		Italic(true)
+
+	StyleLevelFatal = lipgloss.NewStyle().
+		Foreground(ColorTextBright).
+		Background(ColorFatal).
+		Bold(true).
+		Padding(0, 1)
+
+	StyleLevelAlert = lipgloss.NewStyle().
+		Foreground(ColorBg).
+		Background(ColorAlert).
+		Bold(true).
+		Padding(0, 1)
+
+	StyleLevelCritical = lipgloss.NewStyle().
+		Foreground(ColorTextBright).
+		Background(ColorCritical).
+		Bold(true).
+		Padding(0, 1)
 
 	StyleLevelError = lipgloss.NewStyle().
-			Foreground(ColorBg).
-			Background(ColorError).
-			Bold(true).
-			Padding(0, 1)
+		Foreground(ColorBg).
+		Background(ColorError).
+		Bold(true).
+		Padding(0, 1)
 
 	StyleLevelWarn = lipgloss.NewStyle().
-			Foreground(ColorBg).
-			Background(ColorWarn).
-			Bold(true).
-			Padding(0, 1)
+		Foreground(ColorBg).
+		Background(ColorWarn).
+		Bold(true).
+		Padding(0, 1)
+
+	StyleLevelNotice = lipgloss.NewStyle().
+		Foreground(ColorBg).
+		Background(ColorNotice).
+		Bold(true).
+		Padding(0, 1)
 
 	StyleLevelInfo = lipgloss.NewStyle().
-			Foreground(ColorBg).
-			Background(ColorInfo).
-			Bold(true).
-			Padding(0, 1)
+		Foreground(ColorBg).
+		Background(ColorInfo).
+		Bold(true).
+		Padding(0, 1)
 
 	StyleLevelDebug = lipgloss.NewStyle().
-			Foreground(ColorBg).
-			Background(ColorDebug).
-			Bold(true).
-			Padding(0, 1)
+		Foreground(ColorBg).
+		Background(ColorDebug).
+		Bold(true).
+		Padding(0, 1)
 
 	StyleLevelTrace = lipgloss.NewStyle().
-			Foreground(ColorBg).
-			Background(ColorTrace).
-			Padding(0, 1)
+		Foreground(ColorBg).
+		Background(ColorTrace).
+		Padding(0, 1)
 
 	StyleLevelUnknown = lipgloss.NewStyle().
-				Foreground(ColorTextMuted).
-				Padding(0, 1)
+		Foreground(ColorTextMuted).
+		Padding(0, 1)
 
 	StyleFilter = lipgloss.NewStyle().
-			Foreground(ColorAccent).
-			Bold(true)
+		Foreground(ColorAccent).
+		Bold(true)
 
 	StyleFilterInput = lipgloss.NewStyle().
-			Foreground(ColorTextBright).
-			Bold(true)
+		Foreground(ColorTextBright).
+		Bold(true)
 
 	StyleFilterActive = lipgloss.NewStyle().
-				Foreground(ColorAccent)
+		Foreground(ColorAccent)
 
 	StyleStatus = lipgloss.NewStyle().
-			Foreground(ColorSuccess)
+		Foreground(ColorSuccess)
 
 	StyleEmpty = lipgloss.NewStyle().
-			Foreground(ColorTextMuted)
+		Foreground(ColorTextMuted)
 
 	StyleEmptyBox = lipgloss.NewStyle().
-			Foreground(ColorBorder)
+		Foreground(ColorBorder)
 
 	StyleDetailHeader = lipgloss.NewStyle().
-				Foreground(ColorAccent).
-				Bold(true)
+		Foreground(ColorAccent).
+		Bold(true)
 
 	StyleDetailLabel = lipgloss.NewStyle().
-			Foreground(ColorAccent).
-			Bold(true)
+		Foreground(ColorAccent).
+		Bold(true)
 
 	StyleDetailValue = lipgloss.NewStyle().
-			Foreground(ColorTextPrimary)
+		Foreground(ColorTextPrimary)
 
 	StyleDetailDim = lipgloss.NewStyle().
-			Foreground(ColorTextMuted)
+		Foreground(ColorTextMuted)
 
 	StyleJSONKey = lipgloss.NewStyle().
-			Foreground(ColorAccent).
-			Bold(true)
+		Foreground(ColorAccent).
+		Bold(true)
 
 	StyleJSONString = lipgloss.NewStyle().
-			Foreground(ColorSuccess)
+		Foreground(ColorSuccess)
 
 	StyleJSONNumber = lipgloss.NewStyle().
-			Foreground(ColorInfo)
+		Foreground(ColorInfo)
 
 	StyleJSONBool = lipgloss.NewStyle().
-			Foreground(ColorDebug)
+		Foreground(ColorDebug)
 
 	StyleJSONNull = lipgloss.NewStyle().
-			Foreground(ColorTextMuted)
+		Foreground(ColorTextMuted)
 
 	StyleHelp = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorBorder).
-			Padding(1, 2).
-			Background(ColorBgPanel)
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBorder).
+		Padding(1, 2).
+		Background(ColorBgPanel)
 
 	StyleHelpSection = lipgloss.NewStyle().
-			Foreground(ColorAccent).
-			Bold(true)
+		Foreground(ColorAccent).
+		Bold(true)
 
 	StyleHelpKey = lipgloss.NewStyle().
-			Foreground(ColorTextBright).
-			Bold(true)
+		Foreground(ColorTextBright).
+		Bold(true)
 
 	StyleHelpDesc = lipgloss.NewStyle().
-			Foreground(ColorTextSecondary)
+		Foreground(ColorTextSecondary)
 
 	StyleFooter = lipgloss.NewStyle().
-			Foreground(ColorTextMuted)
+		Foreground(ColorTextMuted)
 
 	StyleCredit = lipgloss.NewStyle().
-			Foreground(ColorTextSecondary)
+		Foreground(ColorTextSecondary)
 
 	StyleNotesInput = lipgloss.NewStyle().
-			Foreground(ColorTextPrimary)
+		Foreground(ColorTextPrimary)
 
 	StyleNotesHeader = lipgloss.NewStyle().
-			Foreground(ColorAccent).
-			Bold(true)
+		Foreground(ColorAccent).
+		Bold(true)
 
 	StyleNoteIndicator = lipgloss.NewStyle().
-				Foreground(ColorAccent).
-				Bold(true)
+		Foreground(ColorAccent).
+		Bold(true)
 
 	StyleNoteBox = lipgloss.NewStyle().
-			Background(ColorNoteBox).
-			Foreground(ColorTextPrimary)
+		Background(ColorNoteBox).
+		Foreground(ColorTextPrimary)
 
 	StyleNoteBoxBorder = lipgloss.NewStyle().
-				Background(ColorNoteBox).
-				Foreground(ColorAccent)
+		Background(ColorNoteBox).
+		Foreground(ColorAccent)
 
 	StyleLookupInput = lipgloss.NewStyle().
-			Foreground(ColorAccent).
-			Bold(true)
+		Foreground(ColorAccent).
+		Bold(true)
 
 	StyleLookupResult = lipgloss.NewStyle().
-			Foreground(ColorTextPrimary)
+		Foreground(ColorTextPrimary)
 
 	StyleLookupSelected = lipgloss.NewStyle().
-				Background(ColorAccent).
-				Foreground(ColorBg).
-				Bold(true)
+		Background(ColorAccent).
+		Foreground(ColorBg).
+		Bold(true)
 
 	StyleFrameBorder = lipgloss.NewStyle().
-				Foreground(ColorBorder)
+		Foreground(ColorBorder)
 
 	StyleModalInner = lipgloss.NewStyle().
-			Background(ColorBg).
-			Foreground(ColorTextPrimary)
+		Background(ColorBg).
+		Foreground(ColorTextPrimary)
 
 	StyleModalText = lipgloss.NewStyle().
-			Background(ColorBg).
-			Foreground(ColorTextSecondary)
+		Background(ColorBg).
+		Foreground(ColorTextSecondary)
 
 	StyleModalHighlight = lipgloss.NewStyle().
-				Background(ColorBg).
-				Foreground(ColorTextBright).
-				Bold(true)
+		Background(ColorBg).
+		Foreground(ColorTextBright).
+		Bold(true)
 
 	StyleModalDim = lipgloss.NewStyle().
-			Background(ColorBg).
-			Foreground(ColorTextMuted)
+		Background(ColorBg).
+		Foreground(ColorTextMuted)
 
 	StyleModalAccent = lipgloss.NewStyle().
-			Background(ColorBg).
-			Foreground(ColorAccent).
-			Bold(true)
-)
+		Background(ColorBg).
+		Foreground(ColorAccent).
+		Bold(true)
+}
 
 func LevelStyle(level logx.Level) lipgloss.Style {
 	switch level {
+	case logx.LevelFatal:
+		return StyleLevelFatal
+	case logx.LevelAlert:
+		return StyleLevelAlert
+	case logx.LevelCritical:
+		return StyleLevelCritical
 	case logx.LevelError:
 		return StyleLevelError
 	case logx.LevelWarn:
 		return StyleLevelWarn
+	case logx.LevelNotice:
+		return StyleLevelNotice
 	case logx.LevelInfo:
 		return StyleLevelInfo
 	case logx.LevelDebug:
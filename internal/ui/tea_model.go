@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/atotto/clipboard"
 	tea "github.com/charmbracelet/bubbletea"
@@ -12,16 +13,22 @@ import (
 	"github.com/kalayciburak/lx/internal/input"
 	"github.com/kalayciburak/lx/internal/logx"
 	"github.com/kalayciburak/lx/internal/lookup"
+	"github.com/kalayciburak/lx/internal/runner"
 	"github.com/kalayciburak/lx/internal/signal"
 )
 
-const (
+var (
 	MaxCopyLines       = 1000
 	MaxSelectLines     = 3000
 	MaxTextFilterLines = 15000
 	LoadingBatchSize   = 10000
 )
 
+const (
+	hScrollStep = 8
+	rateBuckets = 60
+)
+
 type LoadingBatchMsg struct {
 	Entries []logx.Entry
 }
@@ -34,6 +41,26 @@ type LiveBatchMsg struct {
 
 type LiveStoppedMsg struct{}
 
+type LiveErrorMsg struct {
+	Err error
+}
+
+type RunOutputMsg struct {
+	State   *app.State
+	Entries []logx.Entry
+	parser  *runParser
+}
+
+type RunExitMsg struct {
+	State *app.State
+}
+
+type RunTickMsg struct{}
+
+type RunRestartMsg struct {
+	State *app.State
+}
+
 func sanitizeForClipboard(s string) string {
 	return strings.ReplaceAll(s, "\x00", "")
 }
@@ -126,6 +153,7 @@ type Model struct {
 	State           *app.State
 	Width           int
 	Height          int
+	Command         []string
 }
 
 func NewModel(state *app.State) Model {
@@ -139,10 +167,211 @@ func NewModel(state *app.State) Model {
 	}
 }
 
+func (m Model) WithBaseline(baseline *app.State) Model {
+	baseline.Baseline = true
+	m.Workspaces = append([]*app.State{baseline}, m.Workspaces...)
+	m.ActiveWorkspace = len(m.Workspaces) - 1
+	return m
+}
+
 func (m Model) Init() tea.Cmd {
+	if len(m.Command) > 0 {
+		m.State.RunNumber = 1
+		return tea.Batch(m.startRun(m.State), runTick())
+	}
 	return nil
 }
 
+func (m Model) startRun(s *app.State) tea.Cmd {
+	proc, err := runner.Start(m.Command)
+	if err != nil {
+		s.IsLive = false
+		s.StatusMsg = "Start failed: " + err.Error()
+		return nil
+	}
+	s.Run = proc
+	s.IsLive = true
+	return readRun(s, proc, newRunParser())
+}
+
+func readRun(s *app.State, proc *runner.Process, parser *runParser) tea.Cmd {
+	return func() tea.Msg {
+		var line runner.Line
+		var ok bool
+		if parser.pending() {
+			select {
+			case line, ok = <-proc.Lines:
+			case <-time.After(100 * time.Millisecond):
+				return RunOutputMsg{State: s, Entries: parser.flush(), parser: parser}
+			}
+		} else {
+			line, ok = <-proc.Lines
+		}
+		if !ok {
+			if entries := parser.flush(); len(entries) > 0 {
+				return RunOutputMsg{State: s, Entries: entries, parser: parser}
+			}
+			return RunExitMsg{State: s}
+		}
+		batch := []runner.Line{line}
+		timeout := time.After(100 * time.Millisecond)
+		for len(batch) < 100 {
+			select {
+			case line, ok := <-proc.Lines:
+				if !ok {
+					return RunOutputMsg{State: s, Entries: parser.parse(batch), parser: parser}
+				}
+				batch = append(batch, line)
+			case <-timeout:
+				return RunOutputMsg{State: s, Entries: parser.parse(batch), parser: parser}
+			}
+		}
+		return RunOutputMsg{State: s, Entries: parser.parse(batch), parser: parser}
+	}
+}
+
+type runParser struct {
+	streams map[string]*logx.Parser
+	offsets map[string]int
+	queued  map[string][]int
+	index   int
+}
+
+func newRunParser() *runParser {
+	return &runParser{
+		streams: make(map[string]*logx.Parser),
+		offsets: make(map[string]int),
+		queued:  make(map[string][]int),
+	}
+}
+
+func (rp *runParser) parse(lines []runner.Line) []logx.Entry {
+	texts := make(map[string][]string)
+	var order []string
+	for i, line := range lines {
+		if _, ok := texts[line.Stream]; !ok {
+			order = append(order, line.Stream)
+		}
+		texts[line.Stream] = append(texts[line.Stream], line.Text)
+		rp.queued[line.Stream] = append(rp.queued[line.Stream], rp.index+i)
+	}
+	rp.index += len(lines)
+
+	var entries []logx.Entry
+	for _, stream := range order {
+		parser := rp.streams[stream]
+		if parser == nil {
+			parser = logx.NewParser()
+			rp.streams[stream] = parser
+		}
+		entries = append(entries, rp.place(stream, parser.Parse(texts[stream]))...)
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Index < entries[j].Index })
+	return entries
+}
+
+func (rp *runParser) pending() bool {
+	for _, parser := range rp.streams {
+		if parser.Pending() {
+			return true
+		}
+	}
+	return false
+}
+
+func (rp *runParser) flush() []logx.Entry {
+	var entries []logx.Entry
+	for stream, parser := range rp.streams {
+		entries = append(entries, rp.place(stream, parser.Flush())...)
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Index < entries[j].Index })
+	return entries
+}
+
+func (rp *runParser) place(stream string, parsed []logx.Entry) []logx.Entry {
+	queued := rp.queued[stream]
+	for i := range parsed {
+		parsed[i].Index = queued[parsed[i].Index-rp.offsets[stream]]
+		parsed[i].Stream = stream
+	}
+	consumed := len(queued)
+	if rp.streams[stream].Pending() {
+		consumed--
+	}
+	rp.queued[stream] = queued[consumed:]
+	rp.offsets[stream] += consumed
+	return parsed
+}
+
+func runTick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return RunTickMsg{}
+	})
+}
+
+func (m Model) runActive() bool {
+	for _, ws := range m.Workspaces {
+		if ws.Run != nil && !ws.Run.Done() {
+			return true
+		}
+		if ws.Run == nil && ws.RunNumber > 0 && ws.IsLive {
+			return true
+		}
+	}
+	return false
+}
+
+func (m Model) restartRun() (Model, tea.Cmd) {
+	if len(m.Command) == 0 {
+		m.State.StatusMsg = "Nothing to restart (start with lx -- cmd)"
+		return m, nil
+	}
+	if len(m.Workspaces) >= 10 {
+		m.State.StatusMsg = "Max 10 workspaces allowed"
+		return m, nil
+	}
+
+	ticking := m.runActive()
+	runNumber := 0
+	var running []*runner.Process
+	for _, ws := range m.Workspaces {
+		if ws.Run != nil && !ws.Run.Done() {
+			running = append(running, ws.Run)
+		}
+		if ws.RunNumber > runNumber {
+			runNumber = ws.RunNumber
+		}
+	}
+
+	state := app.NewLoadingState(input.ModeCommand, m.State.FileName)
+	state.IsLoading = false
+	state.IsLive = true
+	state.RunNumber = runNumber + 1
+	m.Workspaces = append(m.Workspaces, state)
+	m.ActiveWorkspace = len(m.Workspaces) - 1
+	m.State = state
+	m.State.StatusMsg = "Stopping previous run..."
+
+	cmd := func() tea.Msg {
+		for _, proc := range running {
+			proc.Terminate(3 * time.Second)
+		}
+		return RunRestartMsg{State: state}
+	}
+	if !ticking {
+		return m, tea.Batch(cmd, runTick())
+	}
+	return m, cmd
+}
+
+func (m Model) StopRuns() {
+	for _, ws := range m.Workspaces {
+		if ws.Run != nil {
+			ws.Run.Terminate(3 * time.Second)
+		}
+	}
+}
+
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
@@ -168,6 +397,32 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.State.IsLive = false
 		m.State.StatusMsg = "Stream ended"
 		return m, nil
+	case LiveErrorMsg:
+		if m.State.InputMode == input.ModeListen {
+			m.State.StatusMsg = "Listen error: " + msg.Err.Error()
+		} else {
+			m.State.StatusMsg = "Read error: " + msg.Err.Error()
+		}
+		return m, nil
+	case RunOutputMsg:
+		msg.State.AppendEntries(msg.Entries)
+		return m, readRun(msg.State, msg.State.Run, msg.parser)
+	case RunExitMsg:
+		msg.State.IsLive = false
+		if run := msg.State.Run; run.Err != nil {
+			msg.State.StatusMsg = "Process error: " + run.Err.Error()
+		} else {
+			msg.State.StatusMsg = "Process exited with code " + Itoa(run.ExitCode)
+		}
+		return m, nil
+	case RunRestartMsg:
+		msg.State.StatusMsg = "Restarted (run #" + Itoa(msg.State.RunNumber) + ")"
+		return m, m.startRun(msg.State)
+	case RunTickMsg:
+		if m.runActive() {
+			return m, runTick()
+		}
+		return m, nil
 	}
 	return m, nil
 }
@@ -189,10 +444,53 @@ func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 		} else if m.State.Mode == app.ModeList || m.State.Mode == app.ModeDetail {
 			m.State.MoveCursor(3)
 		}
+	case tea.MouseButtonWheelLeft, tea.MouseButtonWheelRight:
+		if (m.State.Mode == app.ModeList || m.State.Mode == app.ModeDetail) && !m.State.DetailMaximized && !m.State.Wrap {
+			if msg.Button == tea.MouseButtonWheelLeft {
+				m.State.ScrollColumns(-hScrollStep, m.listMessageWidth())
+			} else {
+				m.State.ScrollColumns(hScrollStep, m.listMessageWidth())
+			}
+		}
 	}
 	return m, nil
 }
 
+func (m Model) scrollColumns(msg tea.KeyMsg) {
+	if m.State.Wrap {
+		m.State.StatusMsg = "Wrap is on (" + ShortKeyLabel(ActionWrap) + " to turn off)"
+		return
+	}
+	step := hScrollStep
+	if IsAction(msg, ActionPageLeft, ActionPageRight) {
+		step = m.Width / 2
+	}
+	if IsAction(msg, ActionScrollLeft, ActionPageLeft) {
+		step = -step
+	}
+	m.State.ScrollColumns(step, m.listMessageWidth())
+}
+
+func (m Model) listMessageWidth() int {
+	return ListMessageWidth(m.State, m.contentWidth())
+}
+
+func (m Model) contentWidth() int {
+	if m.Width-2 < 40 {
+		return 40
+	}
+	return m.Width - 2
+}
+
+func (m Model) toggleWrap() {
+	m.State.ToggleWrap()
+	if m.State.Wrap {
+		m.State.StatusMsg = "Wrapping long lines"
+	} else {
+		m.State.StatusMsg = "Truncating long lines"
+	}
+}
+
 func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch m.State.Mode {
 	case app.ModeFilter:
@@ -211,6 +509,16 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleOpenFileMode(msg)
 	case app.ModeQuitConfirm:
 		return m.handleQuitConfirmMode(msg)
+	case app.ModeCorrelation:
+		return m.handleCorrelationMode(msg)
+	case app.ModeColumns:
+		return m.handleColumnsMode(msg)
+	case app.ModeFields:
+		return m.handleFieldsMode(msg)
+	case app.ModeCompare:
+		return m.handleCompareMode(msg)
+	case app.ModeDiff:
+		return m.handleDiffMode(msg)
 	default:
 		return m.handleListMode(msg)
 	}
@@ -218,7 +526,7 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 func (m Model) handleListMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch {
-	case IsKey(msg, KeyQ):
+	case IsAction(msg, ActionQuit):
 		if len(m.Workspaces) > 1 {
 			m.State.Mode = app.ModeQuitConfirm
 			return m, nil
@@ -226,36 +534,47 @@ func (m Model) handleListMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, tea.Quit
 	case IsKey(msg, KeyCtrlC):
 		return m, tea.Quit
-	case IsKey(msg, KeyJ, KeyDown):
+	case IsAction(msg, ActionDown):
 		m.State.MoveCursor(1)
-	case IsKey(msg, KeyK, KeyUp):
+	case IsAction(msg, ActionUp):
 		m.State.MoveCursor(-1)
-	case IsKey(msg, KeyG):
+	case IsAction(msg, ActionScrollLeft, ActionScrollRight, ActionPageLeft, ActionPageRight):
+		m.scrollColumns(msg)
+	case IsAction(msg, ActionWrap):
+		m.toggleWrap()
+	case IsAction(msg, ActionTop):
 		m.State.Cursor = 0
-	case IsKey(msg, KeyShiftG):
+	case IsAction(msg, ActionBottom):
 		if len(m.State.Filtered) > 0 {
 			m.State.Cursor = len(m.State.Filtered) - 1
 		}
-	case IsKey(msg, KeyEnter, KeySpace):
+	case IsAction(msg, ActionDetail):
 		if len(m.State.Filtered) > 0 {
 			m.State.Mode = app.ModeDetail
 		}
-	case IsKey(msg, KeyZ):
+	case IsAction(msg, ActionMaximize):
 		if len(m.State.Filtered) > 0 {
 			m.State.Mode = app.ModeDetail
 			m.State.DetailMaximized = true
 			m.State.DetailScroll = 0
 		}
-	case IsKey(msg, KeySlash):
+	case IsAction(msg, ActionFilter):
 		m.State.Mode = app.ModeFilter
-	case IsKey(msg, KeyCtrlR):
+	case IsAction(msg, ActionExpandContext):
+		if m.State.ExpandContextAt(m.State.SelectedIndex()) {
+			m.State.StatusMsg = "Context +" + Itoa(app.ContextExpandStep) + " lines"
+		} else {
+			m.State.StatusMsg = "No active filter"
+		}
+	case IsAction(msg, ActionClearFilter):
 		m.State.FilterQuery = ""
-		m.State.LevelFilter = app.LevelFilterAll
+		m.State.ClearLevelFilter()
+		m.State.ClearExpandedContext()
 		m.State.Refilter()
 		m.State.StatusMsg = "Filter cleared"
-	case IsKey(msg, KeyQuestion):
+	case IsAction(msg, ActionHelp):
 		m.State.Mode = app.ModeHelp
-	case IsKey(msg, KeyShiftN):
+	case IsAction(msg, ActionNoteEdit):
 		idx := m.State.SelectedIndex()
 		if idx >= 0 {
 			m.State.NoteLineIdx = idx
@@ -269,7 +588,7 @@ func (m Model) handleListMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.State.PrevMode = m.State.Mode
 			m.State.Mode = app.ModeNotes
 		}
-	case IsKey(msg, KeyN):
+	case IsAction(msg, ActionNoteToggle):
 		idx := m.State.SelectedIndex()
 		if idx >= 0 {
 			if m.State.HasNote(idx) {
@@ -278,19 +597,19 @@ func (m Model) handleListMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.State.StatusMsg = "No note on this line"
 			}
 		}
-	case IsKey(msg, KeyU_TR, KeyBracketRight):
+	case IsAction(msg, ActionNoteNext):
 		if nextIdx := m.State.NextNotedLine(); nextIdx >= 0 {
 			m.State.JumpToEntry(nextIdx)
 			m.State.ShowingNotes[nextIdx] = true
 			m.State.StatusMsg = "Note: " + Truncate(m.State.GetNote(nextIdx), 30)
 		}
-	case IsKey(msg, KeyG_TR, KeyBracketLeft):
+	case IsAction(msg, ActionNotePrev):
 		if prevIdx := m.State.PrevNotedLine(); prevIdx >= 0 {
 			m.State.JumpToEntry(prevIdx)
 			m.State.ShowingNotes[prevIdx] = true
 			m.State.StatusMsg = "Note: " + Truncate(m.State.GetNote(prevIdx), 30)
 		}
-	case IsKey(msg, KeyM):
+	case IsAction(msg, ActionNotesAll):
 		if m.State.TotalNotes() > 0 {
 			m.State.ToggleAllNotesDisplay()
 			if m.State.CountShowingNotes() > 0 {
@@ -301,7 +620,7 @@ func (m Model) handleListMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		} else {
 			m.State.StatusMsg = "No notes to show"
 		}
-	case IsKey(msg, KeyS):
+	case IsAction(msg, ActionSelect):
 		idx := m.State.SelectedIndex()
 		if idx >= 0 {
 			m.State.ToggleSelection(idx)
@@ -315,7 +634,7 @@ func (m Model) handleListMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				}
 			}
 		}
-	case IsKey(msg, KeyShiftS):
+	case IsAction(msg, ActionSelectAll):
 		if m.State.SelectionCount() > 0 {
 			m.State.ClearSelection()
 			m.State.StatusMsg = "Selection cleared"
@@ -325,7 +644,7 @@ func (m Model) handleListMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.State.SelectAll()
 			m.State.StatusMsg = "Selected all (" + Itoa(m.State.SelectionCount()) + ")"
 		}
-	case IsKey(msg, KeyShiftD):
+	case IsAction(msg, ActionNoteDelete):
 		idx := m.State.SelectedIndex()
 		if idx >= 0 && m.State.HasNote(idx) {
 			m.State.DeleteNote(idx)
@@ -333,7 +652,7 @@ func (m Model) handleListMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		} else {
 			m.State.StatusMsg = "No note to delete"
 		}
-	case IsKey(msg, KeyCtrlL):
+	case IsAction(msg, ActionLookup):
 		m.State.Mode = app.ModeLookup
 		if entry := m.State.SelectedEntry(); entry != nil {
 			if code := lookup.ExtractHTTPCode(entry.Raw); code > 0 {
@@ -341,23 +660,38 @@ func (m Model) handleListMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.State.UpdateLookup()
 			}
 		}
-	case IsKey(msg, Key1):
-		m.State.SignalResult = signal.ErrorFrequency(m.State.VisibleEntries(), 10)
+	case IsAction(msg, ActionSignalFrequency):
+		m.State.SignalResult = signal.ErrorFrequency(m.State.MatchedEntries(), 10)
+		m.State.SignalCursor = 0
 		m.State.Mode = app.ModeSignal
-	case IsKey(msg, Key2):
+	case IsAction(msg, ActionSignalLifetime):
 		if entry := m.State.SelectedEntry(); entry != nil {
-			m.State.SignalResult = signal.Lifetime(m.State.VisibleEntries(), entry.Message)
+			m.State.SignalResult = signal.Lifetime(m.State.MatchedEntries(), entry.Message)
+			m.State.SignalCursor = 0
 			m.State.Mode = app.ModeSignal
 		}
-	case IsKey(msg, Key3):
+	case IsAction(msg, ActionSignalBurst):
 		if entry := m.State.SelectedEntry(); entry != nil {
-			m.State.SignalResult = signal.DetectBurst(m.State.VisibleEntries(), entry.Message)
+			m.refreshBurst(entry.Message)
 			m.State.Mode = app.ModeSignal
 		}
-	case IsKey(msg, Key4):
-		m.State.SignalResult = signal.Diversity(m.State.VisibleEntries())
+	case IsAction(msg, ActionSignalDiversity):
+		m.State.SignalResult = signal.Diversity(m.State.MatchedEntries())
 		m.State.Mode = app.ModeSignal
-	case IsKey(msg, KeyY):
+	case IsAction(msg, ActionSignalStats):
+		m.openStats()
+	case IsAction(msg, ActionSignalRate):
+		m.refreshRate()
+		m.State.Mode = app.ModeSignal
+	case IsAction(msg, ActionSignalAnomalies):
+		m.State.SignalResult = signal.Anomalies(m.State.MatchedEntries(), 10)
+		m.State.SignalCursor = 0
+		m.State.Mode = app.ModeSignal
+	case IsAction(msg, ActionCorrelate):
+		m.startCorrelation()
+	case IsAction(msg, ActionRestart):
+		return m.restartRun()
+	case IsAction(msg, ActionCopyAll):
 		if len(m.State.Filtered) > MaxCopyLines {
 			m.State.StatusMsg = "Too many lines (" + Itoa(len(m.State.Filtered)) + "). Max " + Itoa(MaxCopyLines)
 		} else {
@@ -373,7 +707,7 @@ func (m Model) handleListMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				}
 			}
 		}
-	case IsKey(msg, KeyC):
+	case IsAction(msg, ActionCopy):
 		if m.State.SelectionCount() > MaxCopyLines {
 			m.State.StatusMsg = "Too many selected (" + Itoa(m.State.SelectionCount()) + "). Max " + Itoa(MaxCopyLines)
 		} else if m.State.SelectionCount() > 0 {
@@ -411,7 +745,7 @@ func (m Model) handleListMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				}
 			}
 		}
-	case IsKey(msg, KeyD):
+	case IsAction(msg, ActionDelete):
 		count := m.State.SelectionCount()
 		m.State.DeleteSelected()
 		if count > 0 {
@@ -419,10 +753,10 @@ func (m Model) handleListMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		} else {
 			m.State.StatusMsg = "Deleted"
 		}
-	case IsKey(msg, KeyX):
+	case IsAction(msg, ActionClear):
 		m.State.ClearAll()
 		m.State.StatusMsg = "Cleared all"
-	case IsKey(msg, KeyP, KeyCtrlV):
+	case IsAction(msg, ActionPaste):
 		content, err := clipboard.ReadAll()
 		if err != nil {
 			m.State.StatusMsg = "Clipboard error"
@@ -433,11 +767,26 @@ func (m Model) handleListMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.State.StatusMsg = "Loaded " + Itoa(len(lines)) + " lines"
 			}
 		}
-	case IsKey(msg, KeyO):
+	case IsAction(msg, ActionOpen):
 		m.State.OpenFilePath = ""
 		m.State.OpenFileCursor = 0
 		m.State.Mode = app.ModeOpenFile
-	case IsKey(msg, KeyShiftT):
+	case IsAction(msg, ActionColumns):
+		m.openColumnPicker(app.ModeList)
+	case IsAction(msg, ActionFields):
+		m.openFieldExplorer(app.ModeList)
+	case IsAction(msg, ActionCompare):
+		m.openCompare(app.ModeList)
+	case IsAction(msg, ActionDiff):
+		m.openDiff()
+	case IsAction(msg, ActionANSI):
+		m.State.ShowANSI = !m.State.ShowANSI
+		if m.State.ShowANSI {
+			m.State.StatusMsg = "Showing original colors"
+		} else {
+			m.State.StatusMsg = "Hiding original colors"
+		}
+	case IsAction(msg, ActionWorkspaceNew):
 		if len(m.Workspaces) >= 10 {
 			m.State.StatusMsg = "Max 10 workspaces allowed"
 		} else {
@@ -447,19 +796,19 @@ func (m Model) handleListMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.State = m.Workspaces[m.ActiveWorkspace]
 			m.State.StatusMsg = "Workspace " + Itoa(m.ActiveWorkspace+1) + " created"
 		}
-	case IsKey(msg, KeyTab):
+	case IsAction(msg, ActionWorkspaceNext):
 		if len(m.Workspaces) > 1 {
 			m.ActiveWorkspace = (m.ActiveWorkspace + 1) % len(m.Workspaces)
 			m.State = m.Workspaces[m.ActiveWorkspace]
 			m.State.StatusMsg = "Workspace " + Itoa(m.ActiveWorkspace+1) + "/" + Itoa(len(m.Workspaces))
 		}
-	case IsKey(msg, KeyShiftTab):
+	case IsAction(msg, ActionWorkspacePrev):
 		if len(m.Workspaces) > 1 {
 			m.ActiveWorkspace = (m.ActiveWorkspace - 1 + len(m.Workspaces)) % len(m.Workspaces)
 			m.State = m.Workspaces[m.ActiveWorkspace]
 			m.State.StatusMsg = "Workspace " + Itoa(m.ActiveWorkspace+1) + "/" + Itoa(len(m.Workspaces))
 		}
-	case IsKey(msg, KeyShiftW):
+	case IsAction(msg, ActionWorkspaceClose):
 		if len(m.Workspaces) > 1 {
 			m.Workspaces = append(m.Workspaces[:m.ActiveWorkspace], m.Workspaces[m.ActiveWorkspace+1:]...)
 			if m.ActiveWorkspace >= len(m.Workspaces) {
@@ -470,14 +819,14 @@ func (m Model) handleListMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		} else {
 			m.State.StatusMsg = "Cannot close last workspace"
 		}
-	case IsKey(msg, KeyU):
+	case IsAction(msg, ActionUndo):
 		count := m.State.Undo()
 		if count > 0 {
 			m.State.StatusMsg = "Restored " + Itoa(count) + " lines"
 		} else {
 			m.State.StatusMsg = "Nothing to undo"
 		}
-	case IsKey(msg, KeyShiftU):
+	case IsAction(msg, ActionRedo):
 		count := m.State.Redo()
 		if count > 0 {
 			m.State.StatusMsg = "Re-deleted " + Itoa(count) + " lines"
@@ -499,8 +848,14 @@ func (m Model) handleFilterMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if !tooManyLines || m.State.FilterQuery == "" {
 			m.State.Refilter()
 		}
-	case IsKey(msg, KeyTab):
+	case IsAction(msg, ActionLevelCycle):
 		m.State.CycleLevelFilter()
+	case IsAction(msg, ActionLevelRangeEnd):
+		m.State.CycleLevelFilterMax()
+	case IsAction(msg, ActionLevelMode):
+		m.State.CycleLevelMode()
+	case IsAction(msg, ActionContinuation):
+		m.State.ToggleContinuation()
 	case IsKey(msg, KeyBackspace):
 		if len(m.State.FilterQuery) > 0 {
 			m.State.FilterQuery = m.State.FilterQuery[:len(m.State.FilterQuery)-1]
@@ -531,20 +886,20 @@ func (m Model) handleDetailMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		} else {
 			m.State.Mode = app.ModeList
 		}
-	case IsKey(msg, KeyEnter, KeySpace):
+	case IsAction(msg, ActionDetail):
 		m.State.Mode = app.ModeList
 		m.State.DetailMaximized = false
-	case IsKey(msg, KeyZ):
+	case IsAction(msg, ActionMaximize):
 		m.State.DetailMaximized = !m.State.DetailMaximized
 		m.State.DetailScroll = 0
-	case IsKey(msg, KeyJ, KeyDown):
+	case IsAction(msg, ActionDown):
 		if m.State.DetailMaximized {
 			m.State.DetailScroll++
 		} else {
 			m.State.MoveCursor(1)
 			m.State.DetailScroll = 0
 		}
-	case IsKey(msg, KeyK, KeyUp):
+	case IsAction(msg, ActionUp):
 		if m.State.DetailMaximized {
 			m.State.DetailScroll--
 			if m.State.DetailScroll < 0 {
@@ -554,14 +909,20 @@ func (m Model) handleDetailMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.State.MoveCursor(-1)
 			m.State.DetailScroll = 0
 		}
-	case IsKey(msg, KeyPgDn):
+	case IsAction(msg, ActionScrollLeft, ActionScrollRight, ActionPageLeft, ActionPageRight):
+		if !m.State.DetailMaximized {
+			m.scrollColumns(msg)
+		}
+	case IsAction(msg, ActionWrap):
+		m.toggleWrap()
+	case IsAction(msg, ActionPageDown):
 		m.State.DetailScroll += 10
-	case IsKey(msg, KeyPgUp):
+	case IsAction(msg, ActionPageUp):
 		m.State.DetailScroll -= 10
 		if m.State.DetailScroll < 0 {
 			m.State.DetailScroll = 0
 		}
-	case IsKey(msg, KeyShiftN):
+	case IsAction(msg, ActionNoteEdit):
 		idx := m.State.SelectedIndex()
 		if idx >= 0 {
 			m.State.NoteLineIdx = idx
@@ -575,7 +936,7 @@ func (m Model) handleDetailMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.State.PrevMode = app.ModeDetail
 			m.State.Mode = app.ModeNotes
 		}
-	case IsKey(msg, KeyN):
+	case IsAction(msg, ActionNoteToggle):
 		idx := m.State.SelectedIndex()
 		if idx >= 0 {
 			if m.State.HasNote(idx) {
@@ -584,17 +945,17 @@ func (m Model) handleDetailMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.State.StatusMsg = "No note"
 			}
 		}
-	case IsKey(msg, KeyU_TR, KeyBracketRight):
+	case IsAction(msg, ActionNoteNext):
 		if nextIdx := m.State.NextNotedLine(); nextIdx >= 0 {
 			m.State.JumpToEntry(nextIdx)
 			m.State.ShowingNotes[nextIdx] = true
 		}
-	case IsKey(msg, KeyG_TR, KeyBracketLeft):
+	case IsAction(msg, ActionNotePrev):
 		if prevIdx := m.State.PrevNotedLine(); prevIdx >= 0 {
 			m.State.JumpToEntry(prevIdx)
 			m.State.ShowingNotes[prevIdx] = true
 		}
-	case IsKey(msg, KeyC):
+	case IsAction(msg, ActionCopy):
 		if m.State.SelectionCount() > MaxCopyLines {
 			m.State.StatusMsg = "Too many selected (" + Itoa(m.State.SelectionCount()) + "). Max " + Itoa(MaxCopyLines)
 		} else if m.State.SelectionCount() > 0 {
@@ -632,7 +993,7 @@ func (m Model) handleDetailMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				}
 			}
 		}
-	case IsKey(msg, KeyM):
+	case IsAction(msg, ActionNotesAll):
 		if m.State.TotalNotes() > 0 {
 			m.State.ToggleAllNotesDisplay()
 			if m.State.CountShowingNotes() > 0 {
@@ -643,19 +1004,19 @@ func (m Model) handleDetailMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		} else {
 			m.State.StatusMsg = "No notes to show"
 		}
-	case IsKey(msg, KeyG):
+	case IsAction(msg, ActionTop):
 		if m.State.DetailMaximized {
 			m.State.DetailScroll = 0
 		} else {
 			m.State.Cursor = 0
 		}
-	case IsKey(msg, KeyShiftG):
+	case IsAction(msg, ActionBottom):
 		if m.State.DetailMaximized {
 			m.State.DetailScroll = 10000
 		} else if len(m.State.Filtered) > 0 {
 			m.State.Cursor = len(m.State.Filtered) - 1
 		}
-	case IsKey(msg, KeyY):
+	case IsAction(msg, ActionCopyAll):
 		if len(m.State.Filtered) > MaxCopyLines {
 			m.State.StatusMsg = "Too many lines (" + Itoa(len(m.State.Filtered)) + "). Max " + Itoa(MaxCopyLines)
 		} else {
@@ -671,7 +1032,7 @@ func (m Model) handleDetailMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				}
 			}
 		}
-	case IsKey(msg, KeyD):
+	case IsAction(msg, ActionDelete):
 		count := m.State.SelectionCount()
 		m.State.DeleteSelected()
 		if count > 0 {
@@ -679,14 +1040,21 @@ func (m Model) handleDetailMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		} else {
 			m.State.StatusMsg = "Deleted"
 		}
-	case IsKey(msg, KeySlash):
+	case IsAction(msg, ActionFilter):
 		m.State.Mode = app.ModeFilter
-	case IsKey(msg, KeyCtrlR):
+	case IsAction(msg, ActionExpandContext):
+		if m.State.ExpandContextAt(m.State.SelectedIndex()) {
+			m.State.StatusMsg = "Context +" + Itoa(app.ContextExpandStep) + " lines"
+		} else {
+			m.State.StatusMsg = "No active filter"
+		}
+	case IsAction(msg, ActionClearFilter):
 		m.State.FilterQuery = ""
-		m.State.LevelFilter = app.LevelFilterAll
+		m.State.ClearLevelFilter()
+		m.State.ClearExpandedContext()
 		m.State.Refilter()
 		m.State.StatusMsg = "Filter cleared"
-	case IsKey(msg, KeyCtrlL):
+	case IsAction(msg, ActionLookup):
 		m.State.Mode = app.ModeLookup
 		if entry := m.State.SelectedEntry(); entry != nil {
 			if code := lookup.ExtractHTTPCode(entry.Raw); code > 0 {
@@ -694,25 +1062,40 @@ func (m Model) handleDetailMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.State.UpdateLookup()
 			}
 		}
-	case IsKey(msg, Key1):
-		m.State.SignalResult = signal.ErrorFrequency(m.State.VisibleEntries(), 10)
+	case IsAction(msg, ActionSignalFrequency):
+		m.State.SignalResult = signal.ErrorFrequency(m.State.MatchedEntries(), 10)
+		m.State.SignalCursor = 0
 		m.State.Mode = app.ModeSignal
-	case IsKey(msg, Key2):
+	case IsAction(msg, ActionSignalLifetime):
 		if entry := m.State.SelectedEntry(); entry != nil {
-			m.State.SignalResult = signal.Lifetime(m.State.VisibleEntries(), entry.Message)
+			m.State.SignalResult = signal.Lifetime(m.State.MatchedEntries(), entry.Message)
+			m.State.SignalCursor = 0
 			m.State.Mode = app.ModeSignal
 		}
-	case IsKey(msg, Key3):
+	case IsAction(msg, ActionSignalBurst):
 		if entry := m.State.SelectedEntry(); entry != nil {
-			m.State.SignalResult = signal.DetectBurst(m.State.VisibleEntries(), entry.Message)
+			m.refreshBurst(entry.Message)
 			m.State.Mode = app.ModeSignal
 		}
-	case IsKey(msg, Key4):
-		m.State.SignalResult = signal.Diversity(m.State.VisibleEntries())
+	case IsAction(msg, ActionSignalDiversity):
+		m.State.SignalResult = signal.Diversity(m.State.MatchedEntries())
+		m.State.Mode = app.ModeSignal
+	case IsAction(msg, ActionSignalStats):
+		m.openStats()
+	case IsAction(msg, ActionSignalRate):
+		m.refreshRate()
+		m.State.Mode = app.ModeSignal
+	case IsAction(msg, ActionSignalAnomalies):
+		m.State.SignalResult = signal.Anomalies(m.State.MatchedEntries(), 10)
+		m.State.SignalCursor = 0
 		m.State.Mode = app.ModeSignal
-	case IsKey(msg, KeyQuestion):
+	case IsAction(msg, ActionCorrelate):
+		m.startCorrelation()
+	case IsAction(msg, ActionRestart):
+		return m.restartRun()
+	case IsAction(msg, ActionHelp):
 		m.State.Mode = app.ModeHelp
-	case IsKey(msg, KeyShiftT):
+	case IsAction(msg, ActionWorkspaceNew):
 		if len(m.Workspaces) >= 10 {
 			m.State.StatusMsg = "Max 10 workspaces allowed"
 		} else {
@@ -722,19 +1105,19 @@ func (m Model) handleDetailMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.State = m.Workspaces[m.ActiveWorkspace]
 			m.State.StatusMsg = "Workspace " + Itoa(m.ActiveWorkspace+1) + " created"
 		}
-	case IsKey(msg, KeyTab):
+	case IsAction(msg, ActionWorkspaceNext):
 		if len(m.Workspaces) > 1 {
 			m.ActiveWorkspace = (m.ActiveWorkspace + 1) % len(m.Workspaces)
 			m.State = m.Workspaces[m.ActiveWorkspace]
 			m.State.StatusMsg = "Workspace " + Itoa(m.ActiveWorkspace+1) + "/" + Itoa(len(m.Workspaces))
 		}
-	case IsKey(msg, KeyShiftTab):
+	case IsAction(msg, ActionWorkspacePrev):
 		if len(m.Workspaces) > 1 {
 			m.ActiveWorkspace = (m.ActiveWorkspace - 1 + len(m.Workspaces)) % len(m.Workspaces)
 			m.State = m.Workspaces[m.ActiveWorkspace]
 			m.State.StatusMsg = "Workspace " + Itoa(m.ActiveWorkspace+1) + "/" + Itoa(len(m.Workspaces))
 		}
-	case IsKey(msg, KeyShiftW):
+	case IsAction(msg, ActionWorkspaceClose):
 		if len(m.Workspaces) > 1 {
 			m.Workspaces = append(m.Workspaces[:m.ActiveWorkspace], m.Workspaces[m.ActiveWorkspace+1:]...)
 			if m.ActiveWorkspace >= len(m.Workspaces) {
@@ -745,7 +1128,7 @@ func (m Model) handleDetailMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		} else {
 			m.State.StatusMsg = "Cannot close last workspace"
 		}
-	case IsKey(msg, KeyS):
+	case IsAction(msg, ActionSelect):
 		idx := m.State.SelectedIndex()
 		if idx >= 0 {
 			m.State.ToggleSelection(idx)
@@ -759,7 +1142,7 @@ func (m Model) handleDetailMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				}
 			}
 		}
-	case IsKey(msg, KeyShiftS):
+	case IsAction(msg, ActionSelectAll):
 		if m.State.SelectionCount() > 0 {
 			m.State.ClearSelection()
 			m.State.StatusMsg = "Selection cleared"
@@ -769,7 +1152,7 @@ func (m Model) handleDetailMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.State.SelectAll()
 			m.State.StatusMsg = "Selected all (" + Itoa(m.State.SelectionCount()) + ")"
 		}
-	case IsKey(msg, KeyShiftD):
+	case IsAction(msg, ActionNoteDelete):
 		idx := m.State.SelectedIndex()
 		if idx >= 0 && m.State.HasNote(idx) {
 			m.State.DeleteNote(idx)
@@ -777,10 +1160,10 @@ func (m Model) handleDetailMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		} else {
 			m.State.StatusMsg = "No note to delete"
 		}
-	case IsKey(msg, KeyX):
+	case IsAction(msg, ActionClear):
 		m.State.ClearAll()
 		m.State.StatusMsg = "Cleared all"
-	case IsKey(msg, KeyP, KeyCtrlV):
+	case IsAction(msg, ActionPaste):
 		content, err := clipboard.ReadAll()
 		if err != nil {
 			m.State.StatusMsg = "Clipboard error"
@@ -791,18 +1174,33 @@ func (m Model) handleDetailMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.State.StatusMsg = "Loaded " + Itoa(len(lines)) + " lines"
 			}
 		}
-	case IsKey(msg, KeyO):
+	case IsAction(msg, ActionOpen):
 		m.State.OpenFilePath = ""
 		m.State.OpenFileCursor = 0
 		m.State.Mode = app.ModeOpenFile
-	case IsKey(msg, KeyU):
+	case IsAction(msg, ActionColumns):
+		m.openColumnPicker(app.ModeDetail)
+	case IsAction(msg, ActionFields):
+		m.openFieldExplorer(app.ModeDetail)
+	case IsAction(msg, ActionCompare):
+		m.openCompare(app.ModeDetail)
+	case IsAction(msg, ActionDiff):
+		m.openDiff()
+	case IsAction(msg, ActionANSI):
+		m.State.ShowANSI = !m.State.ShowANSI
+		if m.State.ShowANSI {
+			m.State.StatusMsg = "Showing original colors"
+		} else {
+			m.State.StatusMsg = "Hiding original colors"
+		}
+	case IsAction(msg, ActionUndo):
 		count := m.State.Undo()
 		if count > 0 {
 			m.State.StatusMsg = "Restored " + Itoa(count) + " lines"
 		} else {
 			m.State.StatusMsg = "Nothing to undo"
 		}
-	case IsKey(msg, KeyShiftU):
+	case IsAction(msg, ActionRedo):
 		count := m.State.Redo()
 		if count > 0 {
 			m.State.StatusMsg = "Re-deleted " + Itoa(count) + " lines"
@@ -811,7 +1209,7 @@ func (m Model) handleDetailMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 	case IsKey(msg, KeyCtrlC):
 		return m, tea.Quit
-	case IsKey(msg, KeyQ):
+	case IsAction(msg, ActionQuit):
 		m.State.Mode = app.ModeList
 	}
 	return m, nil
@@ -819,7 +1217,7 @@ func (m Model) handleDetailMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 func (m Model) handleHelpMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch {
-	case IsKey(msg, KeyEsc, KeyQuestion, KeyQ):
+	case IsKey(msg, KeyEsc) || IsAction(msg, ActionHelp, ActionQuit):
 		m.State.Mode = app.ModeList
 	case IsKey(msg, KeyCtrlC):
 		return m, tea.Quit
@@ -977,26 +1375,57 @@ func (m Model) handleLookupMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 func (m Model) handleSignalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch {
-	case IsKey(msg, KeyEsc, KeyQ):
+	case IsKey(msg, KeyEsc) || IsAction(msg, ActionQuit):
 		m.State.Mode = app.ModeList
 		m.State.SignalResult = nil
-	case IsKey(msg, KeyJ, KeyDown):
+	case IsAction(msg, ActionDown):
 		if m.State.SignalResult != nil {
 			switch m.State.SignalResult.Type {
-			case signal.SignalLifetime, signal.SignalBurst:
+			case signal.SignalLifetime, signal.SignalBurst, signal.SignalRate:
 				m.State.MoveCursor(1)
 				m.updateSignalForCurrentEntry()
+			case signal.SignalFrequency, signal.SignalAnomaly:
+				m.moveSignalCursor(1)
 			}
 		}
-	case IsKey(msg, KeyK, KeyUp):
+	case IsAction(msg, ActionUp):
 		if m.State.SignalResult != nil {
 			switch m.State.SignalResult.Type {
-			case signal.SignalLifetime, signal.SignalBurst:
+			case signal.SignalLifetime, signal.SignalBurst, signal.SignalRate:
 				m.State.MoveCursor(-1)
 				m.updateSignalForCurrentEntry()
+			case signal.SignalFrequency, signal.SignalAnomaly:
+				m.moveSignalCursor(-1)
 			}
 		}
-	case IsKey(msg, KeyC):
+	case IsAction(msg, ActionPanelNext, ActionPanelPrev) && m.State.SignalResult != nil && m.State.SignalResult.Type == signal.SignalBurst:
+		if n := len(m.State.SignalResult.Burst.Bursts); n > 0 {
+			delta := 1
+			if IsAction(msg, ActionPanelPrev) {
+				delta = n - 1
+			}
+			m.State.SignalCursor = (m.State.SignalCursor + delta) % n
+		}
+	case IsAction(msg, ActionPanelNext, ActionPanelPrev) && m.State.SignalResult != nil && m.State.SignalResult.Type == signal.SignalLifetime:
+		if !m.State.SignalResult.Lifetime.IsSingle {
+			m.State.SignalCursor = 1 - m.State.SignalCursor
+		}
+	case IsAction(msg, ActionPanelOpen):
+		m.followSignal()
+	case IsAction(msg, ActionPanelNext) && m.State.SignalResult != nil && m.State.SignalResult.Type == signal.SignalRate:
+		m.State.RateAllVisible = !m.State.RateAllVisible
+		m.refreshRate()
+	case IsAction(msg, ActionPanelNext, ActionPanelPrev):
+		if m.State.SignalResult == nil || m.State.SignalResult.Type != signal.SignalStats {
+			break
+		}
+		if IsAction(msg, ActionPanelNext) {
+			m.State.StatsFieldIdx = (m.State.StatsFieldIdx + 1) % len(m.State.StatsFields)
+		} else {
+			m.State.StatsGroupIdx = (m.State.StatsGroupIdx + 1) % (len(m.State.StatsGroupFields) + 1)
+		}
+		m.refreshStats()
+	case IsAction(msg, ActionCopy):
 		if m.State.SignalResult != nil {
 			content := m.State.SignalResult.FormatForClipboard()
 			if err := clipboard.WriteAll(sanitizeForClipboard(content)); err != nil {
@@ -1013,6 +1442,389 @@ func (m Model) handleSignalMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+func (m Model) openColumnPicker(prev app.Mode) {
+	m.State.OpenColumnPicker()
+	if len(m.State.ColumnChoices) == 0 {
+		m.State.Mode = prev
+		m.State.StatusMsg = "No structured fields to show as columns"
+		return
+	}
+	m.State.PrevMode = prev
+}
+
+func (m Model) handleColumnsMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := m.State.SelectedColumnChoice()
+	switch {
+	case IsKey(msg, KeyEsc) || IsAction(msg, ActionQuit, ActionColumns):
+		m.State.Mode = m.State.PrevMode
+		m.State.ColumnChoices = nil
+	case IsAction(msg, ActionDown):
+		if m.State.ColumnCursor < len(m.State.ColumnChoices)-1 {
+			m.State.ColumnCursor++
+		}
+	case IsAction(msg, ActionUp):
+		if m.State.ColumnCursor > 0 {
+			m.State.ColumnCursor--
+		}
+	case IsAction(msg, ActionTop):
+		m.State.ColumnCursor = 0
+	case IsAction(msg, ActionBottom):
+		if len(m.State.ColumnChoices) > 0 {
+			m.State.ColumnCursor = len(m.State.ColumnChoices) - 1
+		}
+	case IsKey(msg, KeyEnter, KeySpace):
+		if key != "" {
+			m.State.ToggleColumn(key)
+		}
+	case IsAction(msg, ActionScrollLeft, ActionScrollRight):
+		if key == "" || !m.State.HasColumn(key) {
+			break
+		}
+		for _, col := range ListColumns(m.State) {
+			if col.Key != key {
+				continue
+			}
+			if IsAction(msg, ActionScrollLeft) {
+				m.State.ResizeColumn(key, col.Width-1)
+			} else {
+				m.State.ResizeColumn(key, col.Width+1)
+			}
+		}
+	case IsAction(msg, ActionClear):
+		m.State.ClearColumns()
+	case IsKey(msg, KeyCtrlC):
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m Model) openFieldExplorer(prev app.Mode) {
+	m.State.OpenFieldExplorer()
+	if len(m.State.FieldExplorer) == 0 {
+		m.State.Mode = prev
+		m.State.StatusMsg = "No structured fields in visible lines"
+		return
+	}
+	m.State.PrevMode = prev
+}
+
+func (m Model) handleFieldsMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	stat := m.State.SelectedFieldStat()
+	if stat == nil {
+		m.State.Mode = m.State.PrevMode
+		return m, nil
+	}
+	switch {
+	case IsKey(msg, KeyEsc) || IsAction(msg, ActionQuit, ActionFields):
+		m.State.Mode = m.State.PrevMode
+		m.State.FieldExplorer = nil
+	case IsAction(msg, ActionDown):
+		if m.State.FieldFocusValues {
+			if m.State.FieldValueCursor < len(stat.Values)-1 {
+				m.State.FieldValueCursor++
+			}
+		} else if m.State.FieldCursor < len(m.State.FieldExplorer)-1 {
+			m.State.FieldCursor++
+			m.State.FieldValueCursor = 0
+		}
+	case IsAction(msg, ActionUp):
+		if m.State.FieldFocusValues {
+			if m.State.FieldValueCursor > 0 {
+				m.State.FieldValueCursor--
+			}
+		} else if m.State.FieldCursor > 0 {
+			m.State.FieldCursor--
+			m.State.FieldValueCursor = 0
+		}
+	case IsAction(msg, ActionPanelNext, ActionScrollRight):
+		m.State.FieldFocusValues = true
+	case IsAction(msg, ActionPanelPrev, ActionScrollLeft):
+		m.State.FieldFocusValues = false
+	case IsAction(msg, ActionPanelOpen) || IsKey(msg, KeyExclaim):
+		if !m.State.FieldFocusValues {
+			m.State.FieldFocusValues = true
+			break
+		}
+		if m.State.FieldValueCursor >= len(stat.Values) {
+			break
+		}
+		term := logx.FieldTerm(stat.Key, stat.Values[m.State.FieldValueCursor].Value, IsKey(msg, KeyExclaim))
+		m.State.AddFilterTerm(term)
+		m.State.Mode = m.State.PrevMode
+		m.State.FieldExplorer = nil
+		m.State.StatusMsg = "Filter: " + term
+	case IsKey(msg, KeyCtrlC):
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m Model) compareBaseline() *app.State {
+	if len(m.Workspaces) < 2 {
+		return nil
+	}
+	for _, ws := range m.Workspaces {
+		if ws != m.State && ws == m.State.CompareBase {
+			return ws
+		}
+	}
+	for _, ws := range m.Workspaces {
+		if ws != m.State && ws.Baseline {
+			return ws
+		}
+	}
+	return m.Workspaces[(m.ActiveWorkspace+len(m.Workspaces)-1)%len(m.Workspaces)]
+}
+
+func (m Model) openCompare(prev app.Mode) {
+	base := m.compareBaseline()
+	if base == nil {
+		m.State.StatusMsg = "Compare needs a second workspace or --baseline"
+		return
+	}
+	m.State.CompareBase = base
+	m.State.Compare = signal.Compare(base.MatchedEntries(), m.State.MatchedEntries(), m.State.Templates())
+	m.State.CompareCursor = 0
+	m.State.PrevMode = prev
+	m.State.Mode = app.ModeCompare
+}
+
+func (m Model) workspaceIndex(s *app.State) int {
+	for i, ws := range m.Workspaces {
+		if ws == s {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m Model) handleCompareMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	result := m.State.Compare
+	switch {
+	case IsKey(msg, KeyEsc) || IsAction(msg, ActionQuit, ActionCompare):
+		m.State.Mode = m.State.PrevMode
+		m.State.Compare = nil
+	case IsAction(msg, ActionDown):
+		if m.State.CompareCursor < len(result.Items)-1 {
+			m.State.CompareCursor++
+		}
+	case IsAction(msg, ActionUp):
+		if m.State.CompareCursor > 0 {
+			m.State.CompareCursor--
+		}
+	case IsAction(msg, ActionTop):
+		m.State.CompareCursor = 0
+	case IsAction(msg, ActionBottom):
+		if len(result.Items) > 0 {
+			m.State.CompareCursor = len(result.Items) - 1
+		}
+	case IsAction(msg, ActionPanelNext):
+		if len(m.Workspaces) > 2 {
+			i := m.workspaceIndex(m.State.CompareBase)
+			for {
+				i = (i + 1) % len(m.Workspaces)
+				if m.Workspaces[i] != m.State {
+					break
+				}
+			}
+			m.State.CompareBase = m.Workspaces[i]
+			m.openCompare(m.State.PrevMode)
+		}
+	case IsAction(msg, ActionCopy):
+		if err := clipboard.WriteAll(sanitizeForClipboard(result.FormatForClipboard())); err != nil {
+			m.State.StatusMsg = "Clipboard error"
+		} else {
+			m.State.StatusMsg = "Copied comparison"
+		}
+	case IsAction(msg, ActionPanelOpen):
+		if m.State.CompareCursor >= len(result.Items) {
+			break
+		}
+		item := result.Items[m.State.CompareCursor]
+		target, side := m.State, item.Current
+		if item.Current.Count == 0 {
+			target, side = m.State.CompareBase, item.Base
+		}
+		m.State.Mode = m.State.PrevMode
+		m.State.Compare = nil
+		if i := m.workspaceIndex(target); i >= 0 && target != m.State {
+			m.ActiveWorkspace = i
+			m.State = target
+			m.State.Mode = app.ModeList
+		}
+		if idx := m.State.MatchedEntryIndex(side.Entry); idx >= 0 && m.State.JumpToEntry(idx) {
+			m.State.StatusMsg = "Workspace " + Itoa(m.ActiveWorkspace+1) + " line " + Itoa(idx+1)
+		}
+	case IsKey(msg, KeyCtrlC):
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m Model) openDiff() {
+	other := m.compareBaseline()
+	if other == nil {
+		m.State.StatusMsg = "Diff needs a second workspace or --baseline"
+		return
+	}
+	m.State.OpenDiff(other)
+	m.State.CompareBase = other
+	if len(m.State.DiffHunks) == 0 {
+		m.State.StatusMsg = "No differences"
+	}
+}
+
+func (m Model) handleDiffMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	page := m.Height - 6
+	if page < 1 {
+		page = 1
+	}
+	switch {
+	case IsKey(msg, KeyEsc) || IsAction(msg, ActionQuit, ActionDiff):
+		m.State.CloseDiff()
+		m.State.Mode = app.ModeList
+	case IsAction(msg, ActionDown):
+		m.State.ScrollDiff(1)
+	case IsAction(msg, ActionUp):
+		m.State.ScrollDiff(-1)
+	case IsAction(msg, ActionPageDown):
+		m.State.ScrollDiff(page)
+	case IsAction(msg, ActionPageUp):
+		m.State.ScrollDiff(-page)
+	case IsAction(msg, ActionTop):
+		m.State.DiffScroll = 0
+	case IsAction(msg, ActionBottom):
+		m.State.ScrollDiff(len(m.State.DiffRows))
+	case IsKey(msg, KeyN, KeyShiftN) || IsAction(msg, ActionNoteNext, ActionNotePrev):
+		delta := 1
+		if IsKey(msg, KeyShiftN) || IsAction(msg, ActionNotePrev) {
+			delta = -1
+		}
+		if m.State.JumpHunk(delta) {
+			m.State.StatusMsg = "Hunk " + Itoa(m.State.DiffHunk()+1) + "/" + Itoa(len(m.State.DiffHunks))
+		} else {
+			m.State.StatusMsg = "No more hunks"
+		}
+	case IsAction(msg, ActionPanelNext):
+		if len(m.Workspaces) > 2 {
+			i := m.workspaceIndex(m.State.DiffOther)
+			for {
+				i = (i + 1) % len(m.Workspaces)
+				if m.Workspaces[i] != m.State {
+					break
+				}
+			}
+			m.State.CompareBase = m.Workspaces[i]
+			m.openDiff()
+		}
+	case IsAction(msg, ActionPanelOpen):
+		for _, row := range m.State.DiffRows[m.State.DiffScroll:] {
+			if row.B < 0 {
+				continue
+			}
+			if idx := m.State.MatchedEntryIndex(row.B); idx >= 0 && m.State.JumpToEntry(idx) {
+				m.State.StatusMsg = "Line " + Itoa(idx+1)
+			}
+			break
+		}
+		m.State.CloseDiff()
+		m.State.Mode = app.ModeList
+	case IsKey(msg, KeyCtrlC):
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m Model) startCorrelation() {
+	entry := m.State.SelectedEntry()
+	if entry == nil {
+		return
+	}
+	ids := logx.CorrelationIDs(*entry)
+	if len(ids) == 0 {
+		m.State.StatusMsg = "No trace/request ID on this line"
+		return
+	}
+	m.State.CorrelationIDs = ids
+	m.State.CorrelationIDIdx = 0
+	m.correlate()
+	m.State.Mode = app.ModeCorrelation
+}
+
+func (m Model) correlate() {
+	sets := make([][]logx.Entry, len(m.Workspaces))
+	for i, ws := range m.Workspaces {
+		sets[i] = ws.Entries
+	}
+	m.State.Correlation = signal.Correlate(sets, m.State.CorrelationIDs[m.State.CorrelationIDIdx])
+	m.State.CorrelationCursor = 0
+	selected := m.State.SelectedIndex()
+	for i, ev := range m.State.Correlation.Events {
+		if ev.Workspace == m.ActiveWorkspace && ev.Index == selected {
+			m.State.CorrelationCursor = i
+			break
+		}
+	}
+}
+
+func (m Model) handleCorrelationMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	result := m.State.Correlation
+	switch {
+	case IsKey(msg, KeyEsc) || IsAction(msg, ActionQuit):
+		m.State.Mode = app.ModeList
+		m.State.Correlation = nil
+	case IsAction(msg, ActionDown):
+		if m.State.CorrelationCursor < len(result.Events)-1 {
+			m.State.CorrelationCursor++
+		}
+	case IsAction(msg, ActionUp):
+		if m.State.CorrelationCursor > 0 {
+			m.State.CorrelationCursor--
+		}
+	case IsAction(msg, ActionTop):
+		m.State.CorrelationCursor = 0
+	case IsAction(msg, ActionBottom):
+		if len(result.Events) > 0 {
+			m.State.CorrelationCursor = len(result.Events) - 1
+		}
+	case IsAction(msg, ActionPanelNext):
+		if len(m.State.CorrelationIDs) > 1 {
+			m.State.CorrelationIDIdx = (m.State.CorrelationIDIdx + 1) % len(m.State.CorrelationIDs)
+			m.correlate()
+		}
+	case IsAction(msg, ActionCopy):
+		if err := clipboard.WriteAll(sanitizeForClipboard(result.FormatForClipboard())); err != nil {
+			m.State.StatusMsg = "Clipboard error"
+		} else {
+			m.State.StatusMsg = app.CountExport(len(result.Events), "event")
+		}
+	case IsAction(msg, ActionPanelOpen):
+		if m.State.CorrelationCursor >= len(result.Events) {
+			break
+		}
+		ev := result.Events[m.State.CorrelationCursor]
+		m.State.Mode = app.ModeList
+		m.State.Correlation = nil
+		if ev.Workspace < len(m.Workspaces) {
+			m.ActiveWorkspace = ev.Workspace
+			m.State = m.Workspaces[m.ActiveWorkspace]
+		}
+		if !m.State.JumpToEntry(ev.Index) {
+			m.State.FilterQuery = ""
+			m.State.ClearLevelFilter()
+			m.State.ClearExpandedContext()
+			m.State.Refilter()
+			m.State.JumpToEntry(ev.Index)
+			m.State.StatusMsg = "Filter cleared to show line " + Itoa(ev.Index+1)
+		} else {
+			m.State.StatusMsg = "Workspace " + Itoa(m.ActiveWorkspace+1) + " line " + Itoa(ev.Index+1)
+		}
+	case IsKey(msg, KeyCtrlC):
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
 func (m Model) handleOpenFileMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	runes := []rune(m.State.OpenFilePath)
 	cursorPos := m.State.OpenFileCursor
@@ -1128,6 +1940,35 @@ func (m Model) handleQuitConfirmMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+func (m Model) openStats() {
+	numeric, groups := m.State.StatsCandidates()
+	if len(numeric) == 0 {
+		m.State.StatusMsg = "No numeric fields in visible lines"
+		return
+	}
+	field, group := m.State.StatsField(), m.State.StatsGroupBy()
+	m.State.StatsFields = numeric
+	m.State.StatsGroupFields = groups
+	m.State.StatsFieldIdx = 0
+	m.State.StatsGroupIdx = 0
+	for i, f := range numeric {
+		if f == field {
+			m.State.StatsFieldIdx = i
+		}
+	}
+	for i, g := range groups {
+		if g == group {
+			m.State.StatsGroupIdx = i + 1
+		}
+	}
+	m.refreshStats()
+	m.State.Mode = app.ModeSignal
+}
+
+func (m Model) refreshStats() {
+	m.State.SignalResult = signal.NumericStats(m.State.MatchedEntries(), m.State.StatsField(), m.State.StatsGroupBy())
+}
+
 func (m *Model) updateSignalForCurrentEntry() {
 	if m.State.SignalResult == nil {
 		return
@@ -1138,21 +1979,98 @@ func (m *Model) updateSignalForCurrentEntry() {
 	}
 	switch m.State.SignalResult.Type {
 	case signal.SignalLifetime:
-		m.State.SignalResult = signal.Lifetime(m.State.VisibleEntries(), entry.Message)
+		m.State.SignalResult = signal.Lifetime(m.State.MatchedEntries(), entry.Message)
 	case signal.SignalBurst:
-		m.State.SignalResult = signal.DetectBurst(m.State.VisibleEntries(), entry.Message)
+		m.refreshBurst(entry.Message)
+	case signal.SignalRate:
+		m.refreshRate()
 	}
 }
 
+func (m Model) refreshBurst(message string) {
+	m.State.SignalResult = signal.DetectBurst(m.State.MatchedEntries(), message)
+	m.State.SignalCursor = 0
+}
+
+func (m Model) moveSignalCursor(delta int) {
+	n := len(m.State.SignalResult.Frequency)
+	if m.State.SignalResult.Type == signal.SignalAnomaly && m.State.SignalResult.Anomalies != nil {
+		n = len(m.State.SignalResult.Anomalies.Items)
+	}
+	cursor := m.State.SignalCursor + delta
+	if cursor >= n {
+		cursor = n - 1
+	}
+	if cursor < 0 {
+		cursor = 0
+	}
+	m.State.SignalCursor = cursor
+}
+
+func (m Model) followSignal() {
+	r := m.State.SignalResult
+	if r == nil {
+		return
+	}
+	cursor := m.State.SignalCursor
+	switch r.Type {
+	case signal.SignalFrequency:
+		if cursor >= len(r.Frequency) {
+			return
+		}
+		term := m.State.FilterErrorMessage(r.Frequency[cursor].Message)
+		m.State.StatusMsg = "Filter: " + term
+	case signal.SignalLifetime:
+		if r.Lifetime == nil || r.Lifetime.FirstEntry < 0 {
+			return
+		}
+		pos, label := r.Lifetime.FirstEntry, "Jumped to first occurrence"
+		if cursor == 1 {
+			pos, label = r.Lifetime.LastEntry, "Jumped to last occurrence"
+		}
+		m.jumpToMatched(pos, label)
+	case signal.SignalBurst:
+		if r.Burst == nil || cursor >= len(r.Burst.Bursts) {
+			return
+		}
+		b := r.Burst.Bursts[cursor]
+		m.jumpToMatched(b.Entry, "Jumped to burst at "+b.Start.Format("15:04:05"))
+	case signal.SignalAnomaly:
+		if r.Anomalies == nil || cursor >= len(r.Anomalies.Items) {
+			return
+		}
+		m.jumpToMatched(r.Anomalies.Items[cursor].Entry, "Jumped to "+Truncate(r.Anomalies.Items[cursor].Template, 30))
+	default:
+		return
+	}
+	m.State.Mode = app.ModeList
+	m.State.SignalResult = nil
+}
+
+func (m Model) jumpToMatched(pos int, status string) {
+	if idx := m.State.MatchedEntryIndex(pos); idx >= 0 && m.State.JumpToEntry(idx) {
+		m.State.StatusMsg = status
+	}
+}
+
+func (m Model) refreshRate() {
+	template, label := "", "all visible lines"
+	if m.State.FilterQuery != "" {
+		label = "filter " + m.State.FilterQuery
+	}
+	if entry := m.State.SelectedEntry(); entry != nil && !m.State.RateAllVisible {
+		template = m.State.Templates().Get(entry.Message)
+		label = template
+	}
+	m.State.SignalResult = signal.RateTimeline(m.State.MatchedEntries(), template, label, rateBuckets, m.State.Templates())
+}
+
 func (m Model) View() string {
 	if m.Width == 0 || m.Height == 0 {
 		return ""
 	}
-	w := m.Width - 2
+	w := m.contentWidth()
 	h := m.Height
-	if w < 40 {
-		w = 40
-	}
 	if h < 10 {
 		h = 10
 	}
@@ -1174,6 +2092,16 @@ func (m Model) View() string {
 		content = m.renderWithOpenFile(w, h)
 	case app.ModeQuitConfirm:
 		content = m.renderWithQuitConfirm(w, h)
+	case app.ModeCorrelation:
+		content = m.renderCorrelation(w, h)
+	case app.ModeColumns:
+		content = m.renderWithColumns(w, h)
+	case app.ModeFields:
+		content = m.renderWithFields(w, h)
+	case app.ModeCompare:
+		content = m.renderWithCompare(w, h)
+	case app.ModeDiff:
+		content = m.renderDiff(w, h)
 	default:
 		content = m.renderNormal(w, h)
 	}
@@ -1266,7 +2194,7 @@ func (m Model) renderWithLookup(w, h int) string {
 func (m Model) renderWithSignal(w, h int) string {
 	bg := m.renderNormal(w, h)
 	bgLines := splitLines(bg)
-	modal := RenderSignalModal(m.State.SignalResult, h-2, w)
+	modal := RenderSignalModal(m.State.SignalResult, m.State.SignalCursor, h-2, w)
 	modalLines := splitLines(modal)
 	return overlayModal(bgLines, modalLines, w, h-2)
 }
@@ -1275,7 +2203,80 @@ func (m Model) renderWithFilter(w, h int) string {
 	bg := m.renderNormal(w, h)
 	bgLines := splitLines(bg)
 	textFilterDisabled := len(m.State.Entries) > MaxTextFilterLines
-	modal := RenderFilterModal(m.State.FilterQuery, int(m.State.LevelFilter), h-2, w, textFilterDisabled)
+	modal := RenderFilterModal(m.State, h-2, w, textFilterDisabled)
+	modalLines := splitLines(modal)
+	return overlayModal(bgLines, modalLines, w, h-2)
+}
+
+func (m Model) renderCorrelation(w, h int) string {
+	titleBar := RenderTitleBar(m.State, m.ActiveWorkspace, len(m.Workspaces), w)
+	footer := RenderFooter(int(m.State.Mode), m.State.StatusMsg, m.State.SelectionCount(), w)
+	bodyH := h - 2
+	if bodyH < 1 {
+		bodyH = 1
+	}
+	body := RenderCorrelation(m.State.Correlation, m.State.CorrelationCursor, len(m.Workspaces) > 1, bodyH, w)
+	return titleBar + "\n" + body + "\n" + footer
+}
+
+func (m Model) renderDiff(w, h int) string {
+	titleBar := RenderTitleBar(m.State, m.ActiveWorkspace, len(m.Workspaces), w)
+	footer := RenderFooter(int(m.State.Mode), m.State.StatusMsg, m.State.SelectionCount(), w)
+	bodyH := h - 2
+	if bodyH < 1 {
+		bodyH = 1
+	}
+	leftName := "workspace " + Itoa(m.workspaceIndex(m.State.DiffOther)+1)
+	if m.State.DiffOther.FileName != "" {
+		leftName = m.State.DiffOther.FileName
+	}
+	rightName := "workspace " + Itoa(m.ActiveWorkspace+1)
+	if m.State.FileName != "" {
+		rightName = m.State.FileName
+	}
+	body := RenderDiff(m.State, leftName, rightName, bodyH, w)
+	return titleBar + "\n" + body + "\n" + footer
+}
+
+func (m Model) renderWithColumns(w, h int) string {
+	var bg string
+	if m.State.PrevMode == app.ModeDetail {
+		bg = m.renderWithDetail(w, h)
+	} else {
+		bg = m.renderNormal(w, h)
+	}
+	bgLines := splitLines(bg)
+	modal := RenderColumnPicker(m.State, h-2, w)
+	modalLines := splitLines(modal)
+	return overlayModal(bgLines, modalLines, w, h-2)
+}
+
+func (m Model) renderWithFields(w, h int) string {
+	var bg string
+	if m.State.PrevMode == app.ModeDetail {
+		bg = m.renderWithDetail(w, h)
+	} else {
+		bg = m.renderNormal(w, h)
+	}
+	bgLines := splitLines(bg)
+	modal := RenderFieldExplorer(m.State, h-2, w)
+	modalLines := splitLines(modal)
+	return overlayModal(bgLines, modalLines, w, h-2)
+}
+
+func (m Model) renderWithCompare(w, h int) string {
+	var bg string
+	if m.State.PrevMode == app.ModeDetail {
+		bg = m.renderWithDetail(w, h)
+	} else {
+		bg = m.renderNormal(w, h)
+	}
+	bgLines := splitLines(bg)
+	baseName := "workspace " + Itoa(m.workspaceIndex(m.State.CompareBase)+1)
+	if m.State.CompareBase.FileName != "" {
+		baseName = m.State.CompareBase.FileName
+	}
+	modal := RenderCompareModal(m.State, baseName, h-2, w)
 	modalLines := splitLines(modal)
 	return overlayModal(bgLines, modalLines, w, h-2)
 }
@@ -1311,7 +2312,7 @@ func calcDetailHeight(entry *logx.Entry, maxH, w int) int {
 		lines += Min(len(entry.Fields)*2, 12) + 2
 	} else {
 		rawLines := len(entry.Raw) / Max(w-4, 20)
-		lines += Min(rawLines+3, 8)
+		lines += Min(rawLines+3, 8) + len(entry.Fields)
 	}
 	if entry.IsStack {
 		lines += 5
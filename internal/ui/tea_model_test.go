@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/kalayciburak/lx/internal/runner"
+)
+
+func TestRunParser(t *testing.T) {
+	out := func(text string) runner.Line { return runner.Line{Text: text, Stream: runner.StreamStdout} }
+	errLine := func(text string) runner.Line { return runner.Line{Text: text, Stream: runner.StreamStderr} }
+
+	rp := newRunParser()
+	entries := rp.parse([]runner.Line{
+		errLine("panic: boom"),
+		errLine(""),
+		errLine("goroutine 1 [running]:"),
+		out(`{"msg":"tick"}`),
+		errLine("main.main()"),
+		errLine("\t/app/main.go:12 +0x1d"),
+		out("INFO ok"),
+	})
+	entries = append(entries, rp.parse([]runner.Line{
+		out("INFO next"),
+		errLine("\t/app/main.go:20 +0x2a"),
+	})...)
+
+	want := []struct {
+		index  int
+		stream string
+		stack  bool
+	}{
+		{0, runner.StreamStderr, false},
+		{2, runner.StreamStderr, true},
+		{3, runner.StreamStdout, false},
+		{4, runner.StreamStderr, true},
+		{5, runner.StreamStderr, true},
+		{6, runner.StreamStdout, false},
+		{7, runner.StreamStdout, false},
+		{8, runner.StreamStderr, true},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(entries), len(want))
+	}
+	for i, w := range want {
+		e := entries[i]
+		if e.Index != w.index || e.Stream != w.stream || e.IsStack != w.stack {
+			t.Errorf("entry %d (%q) = index %d %s stack %v, want index %d %s stack %v", i, e.Raw, e.Index, e.Stream, e.IsStack, w.index, w.stream, w.stack)
+		}
+	}
+}
+
+func TestRunParserFrameAcrossBatches(t *testing.T) {
+	out := func(text string) runner.Line { return runner.Line{Text: text, Stream: runner.StreamStdout} }
+	errLine := func(text string) runner.Line { return runner.Line{Text: text, Stream: runner.StreamStderr} }
+
+	rp := newRunParser()
+	entries := rp.parse([]runner.Line{out("INFO start"), out("api.handler(0x1)"), errLine("WARN slow")})
+	if len(entries) != 2 || !rp.pending() {
+		t.Fatalf("first batch = %d entries pending %v, want the frame held back", len(entries), rp.pending())
+	}
+	entries = append(entries, rp.parse([]runner.Line{errLine("user.Login(alice)"), out("\t/app/api.go:5 +0x1")})...)
+	entries = append(entries, rp.flush()...)
+	if rp.pending() {
+		t.Error("flush left a pending line")
+	}
+
+	want := []struct {
+		index  int
+		stream string
+		stack  bool
+	}{
+		{0, runner.StreamStdout, false},
+		{2, runner.StreamStderr, false},
+		{1, runner.StreamStdout, true},
+		{4, runner.StreamStdout, true},
+		{3, runner.StreamStderr, false},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(entries), len(want))
+	}
+	for i, w := range want {
+		e := entries[i]
+		if e.Index != w.index || e.Stream != w.stream || e.IsStack != w.stack {
+			t.Errorf("entry %d (%q) = index %d %s stack %v, want index %d %s stack %v", i, e.Raw, e.Index, e.Stream, e.IsStack, w.index, w.stream, w.stack)
+		}
+	}
+}
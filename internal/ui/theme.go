@@ -0,0 +1,267 @@
+package ui
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+const DefaultTheme = "dark"
+
+type Theme struct {
+	Colors map[string]string
+	Lanes  []string
+	ANSI   map[string]string
+}
+
+var darkColors = map[string]string{
+	"accent":         "#FF6B00",
+	"fatal":          "#FF1F5A",
+	"alert":          "#F0642D",
+	"critical":       "#C43030",
+	"error":          "#E54B4B",
+	"warn":           "#E5C07B",
+	"notice":         "#6BB3A8",
+	"info":           "#5B8FB9",
+	"debug":          "#7B68A6",
+	"trace":          "#5B9B8B",
+	"success":        "#6B9B6B",
+	"bg":             "#0C0C10",
+	"bg_alt":         "#121218",
+	"bg_panel":       "#2A2A38",
+	"bg_select":      "#4A2800",
+	"text_primary":   "#D0D0DC",
+	"text_secondary": "#8888A0",
+	"text_muted":     "#505068",
+	"text_bright":    "#EEEEF8",
+	"border":         "#282838",
+	"divider":        "#303040",
+	"note_box":       "#3D3D50",
+}
+
+var darkLanes = []string{"#5B8FB9", "#E5C07B", "#6B9B6B", "#C678DD", "#56B6C2", "#E06C75", "#D19A66", "#98C379"}
+
+var darkANSI = map[string]string{
+	"bg_panel":  "8",
+	"bg_select": "4",
+	"note_box":  "8",
+	"border":    "8",
+	"divider":   "8",
+}
+
+var Themes = map[string]Theme{
+	"dark": {
+		Colors: darkColors,
+		Lanes:  darkLanes,
+		ANSI:   darkANSI,
+	},
+	"light": {
+		Colors: map[string]string{
+			"accent":         "#C2410C",
+			"fatal":          "#BE123C",
+			"alert":          "#C2410C",
+			"critical":       "#991B1B",
+			"error":          "#C62828",
+			"warn":           "#9A6700",
+			"notice":         "#0F766E",
+			"info":           "#1D5FA8",
+			"debug":          "#6A4C9C",
+			"trace":          "#3F7F6F",
+			"success":        "#2E7D32",
+			"bg":             "#FAFAFA",
+			"bg_alt":         "#F0F0F4",
+			"bg_panel":       "#E2E2EA",
+			"bg_select":      "#FFE2C6",
+			"text_primary":   "#24242E",
+			"text_secondary": "#55556A",
+			"text_muted":     "#8A8A9E",
+			"text_bright":    "#000000",
+			"border":         "#C8C8D4",
+			"divider":        "#D4D4DE",
+			"note_box":       "#EDE7DA",
+		},
+		Lanes: []string{"#1D5FA8", "#9A6700", "#2E7D32", "#8E44AD", "#0E7490", "#C62828", "#B45309", "#4D7C0F"},
+		ANSI: map[string]string{
+			"bg_panel":    "7",
+			"bg_select":   "3",
+			"note_box":    "7",
+			"text_bright": "0",
+			"border":      "7",
+			"divider":     "7",
+		},
+	},
+	"high-contrast": {
+		Colors: map[string]string{
+			"accent":         "#FFAF00",
+			"fatal":          "#FF0055",
+			"alert":          "#FF8700",
+			"critical":       "#FF3030",
+			"error":          "#FF5F5F",
+			"warn":           "#FFFF00",
+			"notice":         "#00FFD7",
+			"info":           "#5FAFFF",
+			"debug":          "#D787FF",
+			"trace":          "#87FFAF",
+			"success":        "#5FFF5F",
+			"bg":             "#000000",
+			"bg_alt":         "#000000",
+			"bg_panel":       "#262626",
+			"bg_select":      "#005FAF",
+			"text_primary":   "#FFFFFF",
+			"text_secondary": "#E4E4E4",
+			"text_muted":     "#B2B2B2",
+			"text_bright":    "#FFFFFF",
+			"border":         "#FFFFFF",
+			"divider":        "#808080",
+			"note_box":       "#3A3A3A",
+		},
+		Lanes: []string{"#5FAFFF", "#FFFF00", "#5FFF5F", "#D787FF", "#00FFD7", "#FF5F5F", "#FFAF00", "#FFFFFF"},
+		ANSI: map[string]string{
+			"bg_panel":  "8",
+			"bg_select": "4",
+			"note_box":  "8",
+		},
+	},
+	"colorblind": {
+		Colors: withColors(darkColors, map[string]string{
+			"accent":   "#E69F00",
+			"fatal":    "#D55E00",
+			"alert":    "#E69F00",
+			"critical": "#CC79A7",
+			"error":    "#D55E00",
+			"warn":     "#F0E442",
+			"notice":   "#009E73",
+			"info":     "#56B4E9",
+			"debug":    "#0072B2",
+			"trace":    "#999999",
+			"success":  "#009E73",
+		}),
+		Lanes: []string{"#56B4E9", "#E69F00", "#009E73", "#F0E442", "#0072B2", "#D55E00", "#CC79A7", "#999999"},
+		ANSI:  darkANSI,
+	},
+}
+
+var themeColors = map[string]*lipgloss.TerminalColor{
+	"accent":         &ColorAccent,
+	"fatal":          &ColorFatal,
+	"alert":          &ColorAlert,
+	"critical":       &ColorCritical,
+	"error":          &ColorError,
+	"warn":           &ColorWarn,
+	"notice":         &ColorNotice,
+	"info":           &ColorInfo,
+	"debug":          &ColorDebug,
+	"trace":          &ColorTrace,
+	"success":        &ColorSuccess,
+	"bg":             &ColorBg,
+	"bg_alt":         &ColorBgAlt,
+	"bg_panel":       &ColorBgPanel,
+	"bg_select":      &ColorBgSelect,
+	"text_primary":   &ColorTextPrimary,
+	"text_secondary": &ColorTextSecondary,
+	"text_muted":     &ColorTextMuted,
+	"text_bright":    &ColorTextBright,
+	"border":         &ColorBorder,
+	"divider":        &ColorDivider,
+	"note_box":       &ColorNoteBox,
+}
+
+var colorProfiles = map[string]termenv.Profile{
+	"truecolor": termenv.TrueColor,
+	"256":       termenv.ANSI256,
+	"16":        termenv.ANSI,
+	"none":      termenv.Ascii,
+}
+
+var colorProfile = termenv.TrueColor
+
+func ThemeNames() []string {
+	names := make([]string, 0, len(Themes))
+	for name := range Themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func SetColorProfile(name string) error {
+	if name == "" || name == "auto" {
+		colorProfile = lipgloss.ColorProfile()
+		return nil
+	}
+	profile, ok := colorProfiles[name]
+	if !ok {
+		return errors.New("theme.profile: unknown color profile " + strconv.Quote(name) + " (want auto, truecolor, 256, 16 or none)")
+	}
+	colorProfile = profile
+	lipgloss.SetColorProfile(profile)
+	return nil
+}
+
+func ApplyTheme(name string, overrides map[string]string) error {
+	if name == "" {
+		name = DefaultTheme
+	}
+	theme, ok := Themes[name]
+	if !ok {
+		return errors.New("theme.name: unknown theme " + strconv.Quote(name) + " (want " + strings.Join(ThemeNames(), ", ") + ")")
+	}
+	for role := range overrides {
+		if _, ok := themeColors[role]; !ok {
+			return errors.New("theme: unknown color " + strconv.Quote(role))
+		}
+	}
+
+	for role, ptr := range themeColors {
+		value, custom := overrides[role]
+		if !custom {
+			value = theme.Colors[role]
+		}
+		*ptr = resolveColor(role, value, theme.ANSI, custom)
+	}
+	LaneColors = make([]lipgloss.TerminalColor, len(theme.Lanes))
+	for i, value := range theme.Lanes {
+		LaneColors[i] = resolveColor("", value, nil, false)
+	}
+	buildStyles()
+	return nil
+}
+
+func resolveColor(role, value string, ansi map[string]string, custom bool) lipgloss.TerminalColor {
+	switch colorProfile {
+	case termenv.Ascii:
+		return lipgloss.NoColor{}
+	case termenv.ANSI:
+		if fallback, ok := ansi[role]; ok && !custom {
+			return lipgloss.Color(fallback)
+		}
+		if role == "bg" || role == "bg_alt" {
+			return lipgloss.NoColor{}
+		}
+	}
+	if !strings.HasPrefix(value, "#") {
+		return lipgloss.Color(value)
+	}
+	switch c := colorProfile.Color(value).(type) {
+	case termenv.ANSI256Color:
+		return lipgloss.Color(strconv.Itoa(int(c)))
+	case termenv.ANSIColor:
+		return lipgloss.Color(strconv.Itoa(int(c)))
+	}
+	return lipgloss.Color(value)
+}
+
+func withColors(base, changes map[string]string) map[string]string {
+	colors := make(map[string]string, len(base))
+	for k, v := range base {
+		colors[k] = v
+	}
+	for k, v := range changes {
+		colors[k] = v
+	}
+	return colors
+}
@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func withColorProfile(t *testing.T, profile termenv.Profile) {
+	t.Helper()
+	saved := colorProfile
+	t.Cleanup(func() {
+		colorProfile = saved
+		ApplyTheme(DefaultTheme, nil)
+	})
+	colorProfile = profile
+}
+
+func TestResolveColor(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile termenv.Profile
+		role    string
+		value   string
+		custom  bool
+		want    lipgloss.TerminalColor
+	}{
+		{"truecolor keeps hex", termenv.TrueColor, "info", "#5B8FB9", false, lipgloss.Color("#5B8FB9")},
+		{"256 downsamples", termenv.ANSI256, "info", "#5B8FB9", false, lipgloss.Color("67")},
+		{"16 downsamples", termenv.ANSI, "info", "#5B8FB9", false, lipgloss.Color("12")},
+		{"none drops color", termenv.Ascii, "info", "#5B8FB9", false, lipgloss.NoColor{}},
+		{"ANSI numbers pass through", termenv.ANSI256, "accent", "208", false, lipgloss.Color("208")},
+		{"16 uses the fallback table", termenv.ANSI, "bg_panel", "#2A2A38", false, lipgloss.Color("8")},
+		{"256 ignores the fallback table", termenv.ANSI256, "bg_panel", "#FF0000", false, lipgloss.Color("196")},
+		{"overrides bypass the fallback", termenv.ANSI, "bg_panel", "#FF0000", true, lipgloss.Color("9")},
+		{"16 drops the background", termenv.ANSI, "bg", "#0C0C10", false, lipgloss.NoColor{}},
+		{"16 drops an overridden background", termenv.ANSI, "bg_alt", "#FF0000", true, lipgloss.NoColor{}},
+		{"256 keeps the background", termenv.ANSI256, "bg", "#0C0C10", false, lipgloss.Color("232")},
+	}
+
+	for _, tt := range tests {
+		withColorProfile(t, tt.profile)
+		if got := resolveColor(tt.role, tt.value, darkANSI, tt.custom); got != tt.want {
+			t.Errorf("%s: resolveColor(%q, %q) = %#v, want %#v", tt.name, tt.role, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestApplyTheme(t *testing.T) {
+	withColorProfile(t, termenv.ANSI)
+
+	if err := ApplyTheme("dark", map[string]string{"accent": "#FF0000", "note_box": "#FFFFFF"}); err != nil {
+		t.Fatalf("ApplyTheme() error = %v", err)
+	}
+	tests := []struct {
+		role string
+		got  lipgloss.TerminalColor
+		want lipgloss.TerminalColor
+	}{
+		{"accent", ColorAccent, lipgloss.Color("9")},
+		{"note_box", ColorNoteBox, lipgloss.Color("15")},
+		{"bg_panel", ColorBgPanel, lipgloss.Color("8")},
+		{"bg", ColorBg, lipgloss.NoColor{}},
+		{"info", ColorInfo, lipgloss.Color("12")},
+		{"lane 0", LaneColors[0], lipgloss.Color("12")},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %#v, want %#v", tt.role, tt.got, tt.want)
+		}
+	}
+}
+
+func TestApplyThemeErrors(t *testing.T) {
+	withColorProfile(t, termenv.TrueColor)
+
+	tests := []struct {
+		name      string
+		overrides map[string]string
+		want      string
+	}{
+		{"neon", nil, `theme.name: unknown theme "neon"`},
+		{"dark", map[string]string{"link": "#FFFFFF"}, `theme: unknown color "link"`},
+	}
+	for _, tt := range tests {
+		if err := ApplyTheme(tt.name, tt.overrides); err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("ApplyTheme(%q, %v) error = %v, want %q", tt.name, tt.overrides, err, tt.want)
+		}
+	}
+}
@@ -2,12 +2,19 @@ package ui
 
 import (
 	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/kalayciburak/lx/internal/app"
+	"github.com/kalayciburak/lx/internal/diff"
 	"github.com/kalayciburak/lx/internal/logx"
 	"github.com/kalayciburak/lx/internal/lookup"
+	"github.com/kalayciburak/lx/internal/runner"
 	"github.com/kalayciburak/lx/internal/signal"
 )
 
@@ -34,14 +41,20 @@ func RenderTitleBar(s *app.State, workspaceIdx, workspaceCount, width int) strin
 		parts = append(parts, StyleBarHighlight.Render(Truncate(s.FileName, 25)))
 	}
 
-	if s.IsLive {
+	if s.Baseline {
+		parts = append(parts, StyleBarDim.Render("baseline"))
+	}
+
+	if s.Run != nil {
+		parts = append(parts, renderRunStatus(s))
+	} else if s.IsLive {
 		liveText := "LIVE " + Itoa(len(s.Entries)) + " lines"
 		parts = append(parts, StyleBarAccent.Render("● ")+StyleBarHighlight.Render(liveText))
 	} else if s.IsLoading {
 		loadingText := "Loading... " + Itoa(s.LoadingProgress) + " lines"
 		parts = append(parts, StyleBarAccent.Render("⟳ ")+StyleBarHighlight.Render(loadingText))
 	} else {
-		counts := StyleBarAccent.Render(Itoa(len(s.Filtered))) +
+		counts := StyleBarAccent.Render(Itoa(s.MatchCount())) +
 			StyleBarDim.Render("/"+Itoa(len(s.Entries)))
 		parts = append(parts, counts)
 	}
@@ -58,11 +71,16 @@ func RenderTitleBar(s *app.State, workspaceIdx, workspaceCount, width int) strin
 
 	var right string
 	if s.LevelFilter != app.LevelFilterAll {
-		right = StyleBarAccent.Render("◉ ") + StyleBarHighlight.Render(s.LevelFilter.String())
+		right = StyleBarAccent.Render("◉ ") + StyleBarHighlight.Render(s.LevelFilterLabel())
 	}
 	if s.FilterQuery != "" {
 		right += StyleBarAccent.Render("⚡") + StyleBarText.Render(s.FilterQuery)
 	}
+	if s.Wrap {
+		right += StyleBarAccent.Render(" ↵") + StyleBarText.Render(" wrap")
+	} else if s.HScroll > 0 {
+		right += StyleBarAccent.Render(" ⇠") + StyleBarText.Render(" col "+Itoa(s.HScroll+1))
+	}
 	if s.StatusMsg != "" && !s.IsLoading {
 		if right != "" {
 			right += StyleBarDim.Render("  │  ")
@@ -86,21 +104,61 @@ func RenderTitleBar(s *app.State, workspaceIdx, workspaceCount, width int) strin
 	return content
 }
 
+func renderRunStatus(s *app.State) string {
+	run := s.Run
+	lines := StyleBarDim.Render(" · " + Itoa(len(s.Entries)) + " lines")
+	runtime := StyleBarText.Render(" " + FormatRuntime(run.Runtime()))
+	label := "RUN"
+	if s.RunNumber > 1 {
+		label += " #" + Itoa(s.RunNumber)
+	}
+	if !run.Done() {
+		return StyleBarAccent.Render("▶ ") + StyleBarHighlight.Render(label) + runtime + lines
+	}
+	if run.Err != nil {
+		return StyleBarError.Render("✗ "+label+" failed") + runtime + lines
+	}
+	if run.ExitCode == 0 {
+		return StyleBarSuccess.Render("✓ exit 0") + runtime + lines
+	}
+	return StyleBarError.Render("✗ exit "+Itoa(run.ExitCode)) + runtime + lines
+}
+
 func RenderList(s *app.State, height, width int) string {
 	if len(s.Filtered) == 0 {
 		return RenderEmpty(height, width)
 	}
 
-	maxNum := len(s.Entries)
-	lineNumW := len(Itoa(maxNum)) + 1
-	if lineNumW < 4 {
-		lineNumW = 4
-	}
+	lineNumW := listLineNumWidth(s)
 
 	start := 0
+
+	columns := ListColumns(s)
+	var header string
+	if len(columns) > 0 {
+		header = RenderColumnHeader(columns, lineNumW, width)
+		height--
+		if height < 1 {
+			height = 1
+		}
+	}
 	
-	getItemHeight := func(idx int) int {
+	hiddenBefore := func(i int) int {
+		if !s.ShowHunks || i == 0 {
+			return 0
+		}
+		return s.Filtered[i] - s.Filtered[i-1] - 1
+	}
+
+	getItemHeight := func(i int) int {
+		idx := s.Filtered[i]
 		h := 1
+		if s.Wrap {
+			h = wrappedRows(&s.Entries[idx], lineNumW, width, columns)
+		}
+		if hiddenBefore(i) > 0 {
+			h++
+		}
 		if s.IsNoteShowing(idx) && s.HasNote(idx) {
 			if note, ok := s.GetNoteObj(idx); ok {
 				noteBox := RenderInlineNoteBox(note, width)
@@ -113,47 +171,8 @@ func RenderList(s *app.State, height, width int) string {
 	if s.Cursor >= len(s.Filtered) {
 		s.Cursor = len(s.Filtered) - 1
 	}
-	
-	matchedStart := 0
-	
-	if s.Cursor < height {
-		testLines := 0
-		fits := true
-		for i := 0; i <= s.Cursor; i++ {
-			testLines += getItemHeight(s.Filtered[i])
-			if testLines > height {
-				fits = false
-				break
-			}
-		}
-		if fits {
-			matchedStart = 0
-		} else {
-			needed := 0
-			for i := s.Cursor; i >= 0; i-- {
-				h := getItemHeight(s.Filtered[i])
-				if needed + h > height {
-					matchedStart = i + 1
-					break
-				}
-				needed += h
-				matchedStart = i
-			}
-		}
-	} else {
-		needed := 0
-		for i := s.Cursor; i >= 0; i-- {
-			h := getItemHeight(s.Filtered[i])
-			if needed + h > height {
-				matchedStart = i + 1
-				break
-			}
-			needed += h
-			matchedStart = i
-		}
-	}
-	
-	start = matchedStart
+
+	start = listWindowStart(s.Cursor, height, getItemHeight)
 	
 	var lines []string
 	end := len(s.Filtered)
@@ -167,6 +186,10 @@ func RenderList(s *app.State, height, width int) string {
 
 		itemLines := []string{}
 
+		if hidden := hiddenBefore(i); hidden > 0 {
+			itemLines = append(itemLines, RenderHunkSeparator(hidden, width))
+		}
+
 		if s.IsNoteShowing(entryIdx) && hasNote {
 			if note, ok := s.GetNoteObj(entryIdx); ok {
 				noteBox := RenderInlineNoteBox(note, width)
@@ -175,7 +198,8 @@ func RenderList(s *app.State, height, width int) string {
 			}
 		}
 
-		itemLines = append(itemLines, RenderListLine(&entry, entryIdx+1, lineNumW, width, isSelected, hasNote, isChecked))
+		line := RenderListLine(&entry, entryIdx+1, lineNumW, width, isSelected, hasNote, isChecked, s.ShowANSI, s.IsContextRow(entryIdx), columns, s.HScroll, s.Wrap)
+		itemLines = append(itemLines, strings.Split(line, "\n")...)
 
 		for _, l := range itemLines {
 			if len(lines) < height {
@@ -187,11 +211,173 @@ func RenderList(s *app.State, height, width int) string {
 	for len(lines) < height {
 		lines = append(lines, "")
 	}
+	if header != "" {
+		lines = append([]string{header}, lines...)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+type Column struct {
+	Key   string
+	Width int
+}
+
+const columnSampleSize = 1000
+
+func ListColumns(s *app.State) []Column {
+	columns := make([]Column, 0, len(s.Columns))
+	for _, key := range s.Columns {
+		w, ok := s.ColumnWidths[key]
+		if !ok {
+			w = autoColumnWidth(s, key)
+		}
+		columns = append(columns, Column{Key: key, Width: w})
+	}
+	return columns
+}
+
+func autoColumnWidth(s *app.State, key string) int {
+	w := lipgloss.Width(key)
+	for i, idx := range s.Filtered {
+		if i >= columnSampleSize {
+			break
+		}
+		if vw := lipgloss.Width(ColumnValue(&s.Entries[idx], key)); vw > w {
+			w = vw
+		}
+	}
+	if w > 24 {
+		w = 24
+	}
+	if w < app.ColumnMinWidth {
+		w = app.ColumnMinWidth
+	}
+	return w
+}
+
+func ColumnValue(entry *logx.Entry, key string) string {
+	val, ok := logx.LookupField(entry.Fields, key)
+	if !ok {
+		return ""
+	}
+	return strings.ReplaceAll(formatValue(val), "\n", " ")
+}
+
+func RenderColumnHeader(columns []Column, lineNumW, width int) string {
+	parts := []string{strings.Repeat(" ", lineNumW+4)}
+	for _, col := range columns {
+		parts = append(parts, PadRight(TruncateVisual(col.Key, col.Width), col.Width))
+	}
+	parts = append(parts, "message")
+	return StyleDetailLabel.Render(TruncateVisual(strings.Join(parts, " "), width))
+}
+
+func RenderHunkSeparator(hidden, width int) string {
+	label := " " + Itoa(hidden) + " lines hidden "
+	if hidden == 1 {
+		label = " 1 line hidden "
+	}
+	left := 6
+	right := width - left - lipgloss.Width(label)
+	if right < 0 {
+		right = 0
+	}
+	return StyleHunkSeparator.Render(strings.Repeat("┈", left) + label + strings.Repeat("┈", right))
+}
+
+func listLineNumWidth(s *app.State) int {
+	lineNumW := len(Itoa(len(s.Entries))) + 1
+	if lineNumW < 4 {
+		lineNumW = 4
+	}
+	return lineNumW
+}
+
+func listWindowStart(cursor, height int, itemHeight func(int) int) int {
+	start, needed := cursor, 0
+	for i := cursor; i >= 0; i-- {
+		h := itemHeight(i)
+		if needed+h > height && i < cursor {
+			return i + 1
+		}
+		needed += h
+		start = i
+	}
+	return start
+}
+
+func ListMessageWidth(s *app.State, width int) int {
+	entry := s.SelectedEntry()
+	if entry == nil {
+		return width
+	}
+	_, msgW := messageWidth(listLineParts(entry, 0, listLineNumWidth(s), false, false, false, ListColumns(s)), width)
+	return msgW
+}
+
+func wrappedRows(entry *logx.Entry, lineNumW, width int, columns []Column) int {
+	_, msgW := messageWidth(listLineParts(entry, 0, lineNumW, false, false, false, columns), width)
+	return strings.Count(ansi.Wrap(entry.Message, msgW, ""), "\n") + 1
+}
+
+func messageWidth(parts []string, width int) (usedW, msgW int) {
+	for _, p := range parts {
+		usedW += lipgloss.Width(p) + 1
+	}
+	msgW = width - usedW - 2
+	if msgW < 10 {
+		msgW = 10
+	}
+	return usedW, msgW
+}
+
+func RenderListLine(entry *logx.Entry, lineNum, lineNumW, width int, selected, hasNote, isChecked, showANSI, isContext bool, columns []Column, hscroll int, wrap bool) string {
+	bg := lipgloss.NewStyle()
+	if selected {
+		bg = bg.Background(ColorBgSelect)
+	}
+
+	parts := listLineParts(entry, lineNum, lineNumW, selected, hasNote, isChecked, columns)
+	usedW, msgW := messageWidth(parts, width)
+
+	rows := []string{TruncateVisual(SkipColumns(entry.Message, hscroll), msgW)}
+	if wrap {
+		rows = strings.Split(ansi.Wrap(entry.Message, msgW, ""), "\n")
+	}
+
+	renderMsg := func(msg string) string {
+		if selected {
+			return StyleMessage.Copy().Background(ColorBgSelect).Render(msg)
+		} else if isContext {
+			return StyleContextMessage.Render(msg)
+		} else if showANSI && hscroll == 0 && !wrap && entry.ANSI != "" && entry.Message == strings.TrimSpace(entry.Raw) {
+			return ansi.Truncate(strings.TrimSpace(entry.ANSI), msgW, "...") + ansiReset
+		} else if entry.Level == logx.LevelFatal {
+			return StyleFatalMessage.Render(msg)
+		}
+		return RenderLxFormat(msg, entry.IsStack)
+	}
+
+	parts = append(parts, renderMsg(rows[0]))
+	lines := []string{strings.Join(parts, bg.Render(" "))}
+	for _, row := range rows[1:] {
+		lines = append(lines, bg.Render(strings.Repeat(" ", usedW))+renderMsg(row))
+	}
+
+	if selected {
+		for i, line := range lines {
+			lineW := lipgloss.Width(line)
+			if lineW < width {
+				lines[i] = line + bg.Render(strings.Repeat(" ", width-lineW))
+			}
+		}
+	}
 
 	return strings.Join(lines, "\n")
 }
 
-func RenderListLine(entry *logx.Entry, lineNum, lineNumW, width int, selected, hasNote, isChecked bool) string {
+func listLineParts(entry *logx.Entry, lineNum, lineNumW int, selected, hasNote, isChecked bool, columns []Column) []string {
 	var parts []string
 
 	bg := lipgloss.NewStyle()
@@ -231,49 +417,38 @@ func RenderListLine(entry *logx.Entry, lineNum, lineNumW, width int, selected, h
 
 	if selected {
 		parts = append(parts, StyleCursorIndicator.Copy().Background(ColorBgSelect).Render(">"))
+	} else if entry.Stream == runner.StreamStderr {
+		parts = append(parts, StyleStderrMarker.Render("┃"))
 	} else {
 		parts = append(parts, " ")
 	}
 
-	levelStr := PadCenter(entry.Level.String(), 7)
-	parts = append(parts, LevelStyle(entry.Level).Render(levelStr))
-
-	if entry.Timestamp != "" {
-		if selected {
-			parts = append(parts, StyleTimestamp.Copy().Background(ColorBgSelect).Render(Truncate(entry.Timestamp, 19)))
-		} else {
-			parts = append(parts, StyleTimestamp.Render(Truncate(entry.Timestamp, 19)))
+	if len(columns) > 0 {
+		for _, col := range columns {
+			val := ColumnValue(entry, col.Key)
+			cell := PadRight(TruncateVisual(val, col.Width), col.Width)
+			if level, ok := logx.ParseLevel(val); ok {
+				parts = append(parts, LevelStyle(level).Copy().Padding(0).Render(cell))
+			} else if selected {
+				parts = append(parts, StyleTimestamp.Copy().Background(ColorBgSelect).Render(cell))
+			} else {
+				parts = append(parts, StyleTimestamp.Render(cell))
+			}
 		}
-	}
-
-	usedW := 0
-	for _, p := range parts {
-		usedW += lipgloss.Width(p) + 1
-	}
-	msgW := width - usedW - 2
-	if msgW < 10 {
-		msgW = 10
-	}
-
-	msg := Truncate(entry.Message, msgW)
-	if selected {
-		styledMsg := StyleMessage.Copy().Background(ColorBgSelect).Render(msg)
-		parts = append(parts, styledMsg)
 	} else {
-		styledMsg := RenderLxFormat(msg, entry.IsStack)
-		parts = append(parts, styledMsg)
-	}
+		levelStr := PadCenter(entry.Level.String(), 7)
+		parts = append(parts, LevelStyle(entry.Level).Render(levelStr))
 
-	line := strings.Join(parts, bg.Render(" "))
-
-	if selected {
-		lineW := lipgloss.Width(line)
-		if lineW < width {
-			line += bg.Render(strings.Repeat(" ", width-lineW))
+		if entry.Timestamp != "" {
+			if selected {
+				parts = append(parts, StyleTimestamp.Copy().Background(ColorBgSelect).Render(Truncate(entry.Timestamp, 19)))
+			} else {
+				parts = append(parts, StyleTimestamp.Render(Truncate(entry.Timestamp, 19)))
+			}
 		}
 	}
 
-	return line
+	return parts
 }
 
 func RenderLxFormat(msg string, isStack bool) string {
@@ -522,10 +697,10 @@ func RenderEmpty(height, width int) string {
 	emptyLine()
 
 	content.WriteString(StyleFrameBorder.Render("├" + strings.Repeat("─", modalW-2) + "┤") + "\n")
-	hints := StyleHelpKey.Render("o") + StyleFooter.Render(" open  ") +
-		StyleHelpKey.Render("p") + StyleFooter.Render(" paste  ") +
-		StyleHelpKey.Render("?") + StyleFooter.Render(" help  ") +
-		StyleHelpKey.Render("q") + StyleFooter.Render(" quit")
+	hints := StyleHelpKey.Render(ShortKeyLabel(ActionOpen)) + StyleFooter.Render(" open  ") +
+		StyleHelpKey.Render(ShortKeyLabel(ActionPaste)) + StyleFooter.Render(" paste  ") +
+		StyleHelpKey.Render(ShortKeyLabel(ActionHelp)) + StyleFooter.Render(" help  ") +
+		StyleHelpKey.Render(ShortKeyLabel(ActionQuit)) + StyleFooter.Render(" quit")
 	hintsW := lipgloss.Width(hints)
 	hintsPad := (modalW - 2 - hintsW) / 2
 	if hintsPad < 0 {
@@ -622,7 +797,7 @@ func RenderDetail(s *app.State, entry *logx.Entry, height, width int, maximized
 	if entry.IsJSON && entry.Fields != nil {
 		contentLines = renderJSONDetailLines(entry, width-2)
 	} else {
-		contentLines = renderTextDetailLines(entry, width-2)
+		contentLines = renderTextDetailLines(entry, width-2, s.ShowANSI)
 	}
 
 	if maximized {
@@ -676,14 +851,15 @@ func RenderDetail(s *app.State, entry *logx.Entry, height, width int, maximized
 			visibleLines = visibleLines[:0]
 		}
 		remaining := totalLines - contentH + 2
-		moreLine := "── press z for more (" + Itoa(remaining) + " lines) ──"
+		zKey := ShortKeyLabel(ActionMaximize)
+		moreLine := "── press " + zKey + " for more (" + Itoa(remaining) + " lines) ──"
 		morePad := (width - len(moreLine)) / 2
 		if morePad < 0 {
 			morePad = 0
 		}
 		zStyle := lipgloss.NewStyle().Foreground(ColorInfo).Bold(true)
 		numStyle := lipgloss.NewStyle().Foreground(ColorWarn).Bold(true)
-		styledMore := StyleDetailHeader.Render("── press ") + zStyle.Render("z") + StyleDetailHeader.Render(" for more (") + numStyle.Render(Itoa(remaining)) + StyleDetailHeader.Render(" lines) ──")
+		styledMore := StyleDetailHeader.Render("── press ") + zStyle.Render(zKey) + StyleDetailHeader.Render(" for more (") + numStyle.Render(Itoa(remaining)) + StyleDetailHeader.Render(" lines) ──")
 		visibleLines = append(visibleLines, "")
 		visibleLines = append(visibleLines, strings.Repeat(" ", morePad)+styledMore)
 	}
@@ -739,7 +915,7 @@ func renderJSONDetailLines(entry *logx.Entry, width int) []string {
 	return lines
 }
 
-func renderTextDetailLines(entry *logx.Entry, width int) []string {
+func renderTextDetailLines(entry *logx.Entry, width int, showANSI bool) []string {
 	var lines []string
 
 	if entry.Timestamp != "" {
@@ -748,11 +924,27 @@ func renderTextDetailLines(entry *logx.Entry, width int) []string {
 	if entry.Level != logx.LevelUnknown {
 		lines = append(lines, " "+StyleDetailLabel.Render("Level:")+" "+LevelStyle(entry.Level).Render(" "+entry.Level.String()+" "))
 	}
+	if entry.Stream != "" {
+		lines = append(lines, " "+StyleDetailLabel.Render("Stream:")+" "+StyleDetailValue.Render(entry.Stream))
+	}
+	if len(entry.Fields) > 0 {
+		keys := make([]string, 0, len(entry.Fields))
+		for k := range entry.Fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			lines = append(lines, " "+StyleDetailLabel.Render(k+":")+" "+StyleDetailValue.Render(formatValue(entry.Fields[k])))
+		}
+	}
 
 	lines = append(lines, " "+StyleDetailDim.Render("───"))
 
 	style := lipgloss.NewStyle().Width(width - 2).Foreground(ColorTextPrimary)
 	rendered := style.Render(entry.Raw)
+	if showANSI && entry.ANSI != "" {
+		rendered = lipgloss.NewStyle().Width(width-2).Render(entry.ANSI + ansiReset)
+	}
 	rawLines := strings.Split(rendered, "\n")
 
 	for _, line := range rawLines {
@@ -1143,7 +1335,9 @@ func RenderLookupModal(query string, results []lookup.StatusInfo, cursor, height
 	return result.String()
 }
 
-func RenderFilterModal(query string, levelFilter int, height, width int, textFilterDisabled bool) string {
+func RenderFilterModal(s *app.State, height, width int, textFilterDisabled bool) string {
+	query := s.FilterQuery
+	levelRange := s.LevelRange()
 	modalW := 50
 	if modalW > width-8 {
 		modalW = width - 8
@@ -1179,26 +1373,62 @@ func RenderFilterModal(query string, levelFilter int, height, width int, textFil
 
 	content.WriteString(StyleFrameBorder.Render("│") + pad(modalW-2) + StyleFrameBorder.Render("│") + "\n")
 
-	levelFilters := []string{"ALL", "ERROR", "WARN", "INFO", "DEBUG", "TRACE"}
-	var levelParts []string
-	for i, lf := range levelFilters {
-		if i == levelFilter {
-			levelParts = append(levelParts, StyleModalHighlight.Render("["+lf+"]"))
+	var levelLines []string
+	levelLine := ""
+	for _, lf := range app.LevelFilters {
+		var part string
+		isEnd := lf == s.LevelFilter || (s.LevelMode == app.LevelModeRange && lf == s.LevelFilterMax && lf != app.LevelFilterAll)
+		if isEnd {
+			part = StyleModalHighlight.Render("[" + lf.String() + "]")
+		} else if levelRange != nil && lf != app.LevelFilterAll && levelRange.Contains(lf.Level()) {
+			part = StyleModalAccent.Render(" " + lf.String() + " ")
+		} else {
+			part = StyleModalDim.Render(" " + lf.String() + " ")
+		}
+		if levelLine != "" && lipgloss.Width(levelLine)+lipgloss.Width(part) > innerW {
+			levelLines = append(levelLines, levelLine)
+			levelLine = ""
+		}
+		levelLine += part
+	}
+	levelLines = append(levelLines, levelLine)
+	for _, line := range levelLines {
+		levelLineW := lipgloss.Width(line)
+		levelPadW := (innerW - levelLineW) / 2
+		if levelPadW < 0 {
+			levelPadW = 0
+		}
+		content.WriteString(StyleFrameBorder.Render("│") + pad(1) + pad(levelPadW) + line + pad(innerW-levelPadW-levelLineW) + pad(1) + StyleFrameBorder.Render("│") + "\n")
+	}
+
+	var modeParts []string
+	for _, lm := range app.LevelModes {
+		if lm == s.LevelMode {
+			modeParts = append(modeParts, StyleModalHighlight.Render("["+lm.String()+"]"))
 		} else {
-			levelParts = append(levelParts, StyleModalDim.Render(" "+lf+" "))
+			modeParts = append(modeParts, StyleModalDim.Render(" "+lm.String()+" "))
 		}
 	}
-	levelLine := strings.Join(levelParts, "")
-	levelLineW := lipgloss.Width(levelLine)
-	levelPadW := (innerW - levelLineW) / 2
-	if levelPadW < 0 {
-		levelPadW = 0
+	contLabel := " +cont "
+	if s.IncludeContinuation {
+		modeParts = append(modeParts, StyleModalHighlight.Render("["+strings.TrimSpace(contLabel)+"]"))
+	} else {
+		modeParts = append(modeParts, StyleModalDim.Render(contLabel))
+	}
+	modeLine := strings.Join(modeParts, "")
+	modeLineW := lipgloss.Width(modeLine)
+	modePadW := (innerW - modeLineW) / 2
+	if modePadW < 0 {
+		modePadW = 0
 	}
-	content.WriteString(StyleFrameBorder.Render("│") + pad(1) + pad(levelPadW) + levelLine + pad(innerW-levelPadW-levelLineW) + pad(1) + StyleFrameBorder.Render("│") + "\n")
+	content.WriteString(StyleFrameBorder.Render("│") + pad(1) + pad(modePadW) + modeLine + pad(innerW-modePadW-modeLineW) + pad(1) + StyleFrameBorder.Render("│") + "\n")
 
-	tabHint := StyleModalDim.Render("Tab: cycle levels")
+	tabHint := StyleModalDim.Render(ShortKeyLabel(ActionLevelCycle) + " level  " + ShortKeyLabel(ActionLevelRangeEnd) + " end  " + ShortKeyLabel(ActionLevelMode) + " mode  " + ShortKeyLabel(ActionContinuation) + " cont")
 	tabHintW := lipgloss.Width(tabHint)
 	tabPadW := (innerW - tabHintW) / 2
+	if tabPadW < 0 {
+		tabPadW = 0
+	}
 	content.WriteString(StyleFrameBorder.Render("│") + pad(1) + pad(tabPadW) + tabHint + pad(innerW-tabPadW-tabHintW) + pad(1) + StyleFrameBorder.Render("│") + "\n")
 
 	content.WriteString(StyleFrameBorder.Render("│") + pad(modalW-2) + StyleFrameBorder.Render("│") + "\n")
@@ -1231,6 +1461,7 @@ func RenderFilterModal(query string, levelFilter int, height, width int, textFil
 			{"error", "contains 'error'"},
 			{"!debug", "exclude 'debug'"},
 			{"api timeout", "both terms (AND)"},
+			{"err -C5", "5 lines of context"},
 		}
 		for _, hint := range syntaxHints {
 			hintLine := StyleModalHighlight.Render(PadRight(hint.example, 12)) + StyleModalText.Render(hint.desc)
@@ -1460,9 +1691,9 @@ func RenderOpenFileModal(path string, cursorPos int, suggestions []string, suggI
 }
 
 func RenderHelp(height, width int) string {
-	boxW := 28
-	keyW := 8
-	descW := 16
+	boxW := 29
+	keyW := 10
+	descW := 15
 
 	box := func(title string, rows [][]string, rowCount int) string {
 		var b strings.Builder
@@ -1476,7 +1707,7 @@ func RenderHelp(height, width int) string {
 
 		for i := 0; i < rowCount; i++ {
 			if i < len(rows) {
-				key := PadRight(rows[i][0], keyW)
+				key := PadRight(TruncateVisual(rows[i][0], keyW-1), keyW)
 				desc := PadRight(rows[i][1], descW)
 				b.WriteString(StyleFrameBorder.Render("│") + " " + StyleHelpKey.Render(key) + StyleHelpDesc.Render(desc) + " " + StyleFrameBorder.Render("│") + "\n")
 			} else {
@@ -1488,58 +1719,70 @@ func RenderHelp(height, width int) string {
 	}
 
 	row1Height := 9
-	row2Height := 6
+	row2Height := 9
 
 	nav := box("NAVIGATION", [][]string{
-		{"j/↓", "down"},
-		{"k/↑", "up"},
-		{"g/G", "top/bottom"},
-		{"Enter", "detail"},
-		{"z", "maximize"},
-		{"PgUp/Dn", "scroll detail"},
+		{ShortKeyLabel(ActionDown), "down"},
+		{ShortKeyLabel(ActionUp), "up"},
+		{ShortKeyLabel(ActionTop, ActionBottom), "top/bottom"},
+		{ShortKeyLabel(ActionDetail), "detail"},
+		{ShortKeyLabel(ActionMaximize), "maximize"},
+		{ShortKeyLabel(ActionPageUp, ActionPageDown), "scroll detail"},
+		{ShortKeyLabel(ActionScrollLeft, ActionScrollRight), "scroll ←/→"},
+		{ShortKeyLabel(ActionWrap), "wrap lines"},
 	}, row1Height)
 
 	filter := box("FILTER & NOTES", [][]string{
-		{"/", "filter"},
-		{"Tab", "cycle level"},
-		{"^R", "clear filter"},
-		{"N", "write note"},
-		{"n", "toggle note"},
-		{"m", "show/hide all"},
-		{"]/[", "next/prev note"},
-		{"D", "delete note"},
+		{ShortKeyLabel(ActionFilter), "filter"},
+		{ShortKeyLabel(ActionLevelCycle), "cycle level"},
+		{ShortKeyLabel(ActionClearFilter), "clear filter"},
+		{ShortKeyLabel(ActionFields), "field explorer"},
+		{ShortKeyLabel(ActionNoteEdit), "write note"},
+		{ShortKeyLabel(ActionNoteToggle), "toggle note"},
+		{ShortKeyLabel(ActionNotesAll), "show/hide all"},
+		{ShortKeyLabel(ActionNoteNext, ActionNotePrev), "next/prev note"},
+		{ShortKeyLabel(ActionNoteDelete), "delete note"},
 	}, row1Height)
 
 	selection := box("SELECTION", [][]string{
-		{"s", "toggle select"},
-		{"S", "select all"},
-		{"c", "copy selected"},
-		{"d", "delete selected"},
-		{"y", "copy all"},
-		{"x", "clear all"},
-		{"u/U", "undo/redo"},
-		{"p/^V", "paste"},
+		{ShortKeyLabel(ActionSelect), "toggle select"},
+		{ShortKeyLabel(ActionSelectAll), "select all"},
+		{ShortKeyLabel(ActionCopy), "copy selected"},
+		{ShortKeyLabel(ActionDelete), "delete selected"},
+		{ShortKeyLabel(ActionCopyAll), "copy all"},
+		{ShortKeyLabel(ActionClear), "clear all"},
+		{ShortKeyLabel(ActionUndo, ActionRedo), "undo/redo"},
+		{ShortKeyLabel(ActionPaste), "paste"},
 	}, row1Height)
 
 	signal := box("SIGNAL", [][]string{
-		{"1", "frequency"},
-		{"2", "lifetime"},
-		{"3", "burst"},
-		{"4", "diversity"},
-		{"^L", "HTTP lookup"},
+		{ShortKeyLabel(ActionSignalFrequency), "frequency"},
+		{ShortKeyLabel(ActionSignalLifetime), "lifetime"},
+		{ShortKeyLabel(ActionSignalBurst), "burst"},
+		{ShortKeyLabel(ActionSignalDiversity), "diversity"},
+		{ShortKeyLabel(ActionSignalStats), "numeric stats"},
+		{ShortKeyLabel(ActionSignalRate), "rate timeline"},
+		{ShortKeyLabel(ActionSignalAnomalies), "anomalies"},
+		{ShortKeyLabel(ActionCorrelate), "correlate ID"},
+		{ShortKeyLabel(ActionLookup), "HTTP lookup"},
 	}, row2Height)
 
 	workspace := box("WORKSPACE", [][]string{
-		{"T", "new workspace"},
-		{"W", "close workspace"},
-		{"Tab", "next workspace"},
-		{"S-Tab", "prev workspace"},
+		{ShortKeyLabel(ActionWorkspaceNew), "new workspace"},
+		{ShortKeyLabel(ActionWorkspaceClose), "close workspace"},
+		{ShortKeyLabel(ActionWorkspaceNext), "next workspace"},
+		{ShortKeyLabel(ActionWorkspacePrev), "prev workspace"},
+		{ShortKeyLabel(ActionCompare), "compare baseline"},
+		{ShortKeyLabel(ActionDiff), "line diff"},
 	}, row2Height)
 
 	other := box("OTHER", [][]string{
-		{"o", "open file"},
-		{"?", "this help"},
-		{"q", "quit"},
+		{ShortKeyLabel(ActionOpen), "open file"},
+		{ShortKeyLabel(ActionANSI), "ANSI colors"},
+		{ShortKeyLabel(ActionColumns), "table columns"},
+		{ShortKeyLabel(ActionRestart), "restart cmd"},
+		{ShortKeyLabel(ActionHelp), "this help"},
+		{ShortKeyLabel(ActionQuit), "quit"},
 	}, row2Height)
 
 	navLines := strings.Split(nav, "\n")
@@ -1596,20 +1839,26 @@ func RenderHelp(height, width int) string {
 	return strings.Join(final, "\n")
 }
 
-func RenderQuitConfirm(workspaceCount, height, width int) string {
-	modalW := 44
+func RenderFieldExplorer(s *app.State, height, width int) string {
+	modalW := 84
 	if modalW > width-4 {
 		modalW = width - 4
 	}
+	leftW := (modalW - 7) / 2
+	rightW := modalW - 7 - leftW
 
-	borderStyle := lipgloss.NewStyle().Foreground(ColorWarn)
+	maxRows := height - 6
+	if maxRows > app.FieldTopValues+2 {
+		maxRows = app.FieldTopValues + 2
+	}
+	if maxRows < 1 {
+		maxRows = 1
+	}
 
 	var content strings.Builder
 
-	headerText := " ⚠ QUIT CONFIRMATION "
-	headerStyled := StyleLevelWarn.Bold(true).Render(headerText)
-	headerW := lipgloss.Width(headerStyled)
-	headerPadTotal := modalW - 2 - headerW
+	headerText := " FIELD EXPLORER "
+	headerPadTotal := modalW - 2 - len(headerText)
 	leftPad := headerPadTotal / 2
 	rightPad := headerPadTotal - leftPad
 	if leftPad < 0 {
@@ -1618,50 +1867,69 @@ func RenderQuitConfirm(workspaceCount, height, width int) string {
 	if rightPad < 0 {
 		rightPad = 0
 	}
-	content.WriteString(borderStyle.Render("╔"+strings.Repeat("═", leftPad)) + headerStyled + borderStyle.Render(strings.Repeat("═", rightPad)+"╗") + "\n")
-
-	emptyLine := borderStyle.Render("║") + strings.Repeat(" ", modalW-2) + borderStyle.Render("║") + "\n"
-	content.WriteString(emptyLine)
+	content.WriteString(StyleFrameBorder.Render("╭"+strings.Repeat("─", leftPad)) + StyleDetailHeader.Render(headerText) + StyleFrameBorder.Render(strings.Repeat("─", rightPad)+"╮") + "\n")
 
-	textBright := lipgloss.NewStyle().Foreground(ColorTextBright)
-	countStyled := StyleBarAccent.Bold(true).Render(Itoa(workspaceCount))
-	msgPre := "You have "
-	msgPost := " open workspaces."
-	msgLine := textBright.Render(msgPre) + countStyled + textBright.Render(msgPost)
-	msgLineW := lipgloss.Width(msgLine)
-	msgPad := (modalW - 2 - msgLineW) / 2
-	if msgPad < 0 {
-		msgPad = 0
+	total := len(s.Filtered)
+	stat := s.SelectedFieldStat()
+	if stat == nil {
+		return ""
 	}
-	content.WriteString(borderStyle.Render("║") + strings.Repeat(" ", msgPad) + msgLine + strings.Repeat(" ", modalW-2-msgPad-msgLineW) + borderStyle.Render("║") + "\n")
 
-	msg2 := "Are you sure you want to quit?"
-	msg2Styled := textBright.Bold(true).Render(msg2)
-	msg2W := lipgloss.Width(msg2Styled)
-	msg2Pad := (modalW - 2 - msg2W) / 2
-	if msg2Pad < 0 {
-		msg2Pad = 0
+	fieldHead := PadRight("field", leftW-11) + PadLeft("cov", 5) + PadLeft("uniq", 6)
+	valueHead := PadRight("top values of "+stat.Key, rightW-7) + PadLeft("count", 7)
+	content.WriteString(StyleFrameBorder.Render("│") + " " + StyleDetailLabel.Render(TruncateVisual(fieldHead, leftW)) + " " + StyleFrameBorder.Render("│") + " " + StyleDetailLabel.Render(PadRight(TruncateVisual(valueHead, rightW), rightW)) + " " + StyleFrameBorder.Render("│") + "\n")
+	content.WriteString(StyleFrameBorder.Render("├"+strings.Repeat("─", leftW+2)+"┼"+strings.Repeat("─", rightW+2)+"┤") + "\n")
+
+	start := 0
+	if s.FieldCursor >= maxRows {
+		start = s.FieldCursor - maxRows + 1
 	}
-	content.WriteString(borderStyle.Render("║") + strings.Repeat(" ", msg2Pad) + msg2Styled + strings.Repeat(" ", modalW-2-msg2Pad-msg2W) + borderStyle.Render("║") + "\n")
 
-	content.WriteString(emptyLine)
-	content.WriteString(borderStyle.Render("╠" + strings.Repeat("═", modalW-2) + "╣") + "\n")
+	for row := 0; row < maxRows; row++ {
+		left := strings.Repeat(" ", leftW)
+		if i := start + row; i < len(s.FieldExplorer) {
+			f := s.FieldExplorer[i]
+			cov := 0
+			if total > 0 {
+				cov = f.Count * 100 / total
+			}
+			line := PadRight(TruncateVisual(f.Key, leftW-12), leftW-11) + PadLeft(Itoa(cov)+"%", 5) + PadLeft(Itoa(f.Distinct), 6)
+			switch {
+			case i == s.FieldCursor && !s.FieldFocusValues:
+				left = StyleLookupSelected.Render(line)
+			case i == s.FieldCursor:
+				left = StyleModalHighlight.Render(line)
+			default:
+				left = StyleLookupResult.Render(line)
+			}
+		}
 
-	yStyle := lipgloss.NewStyle().Background(ColorError).Foreground(ColorBg).Bold(true)
-	nStyle := lipgloss.NewStyle().Background(ColorSuccess).Foreground(ColorBg).Bold(true)
-	hints := yStyle.Render(" y ") + textBright.Render(" quit  ") +
-		nStyle.Render(" n/ESC ") + textBright.Render(" cancel")
-	hintsW := lipgloss.Width(hints)
-	hintsPad := (modalW - 2 - hintsW) / 2
-	if hintsPad < 0 {
-		hintsPad = 0
+		right := strings.Repeat(" ", rightW)
+		if row < len(stat.Values) {
+			v := stat.Values[row]
+			value := v.Value
+			if value == "" {
+				value = `""`
+			}
+			line := PadRight(TruncateVisual(value, rightW-8), rightW-7) + PadLeft(Itoa(v.Count), 7)
+			if row == s.FieldValueCursor && s.FieldFocusValues {
+				right = StyleLookupSelected.Render(line)
+			} else {
+				right = StyleLookupResult.Render(line)
+			}
+		}
+
+		content.WriteString(StyleFrameBorder.Render("│") + " " + left + " " + StyleFrameBorder.Render("│") + " " + right + " " + StyleFrameBorder.Render("│") + "\n")
 	}
-	content.WriteString(borderStyle.Render("║") + strings.Repeat(" ", hintsPad) + hints + strings.Repeat(" ", modalW-2-hintsPad-hintsW) + borderStyle.Render("║") + "\n")
-	content.WriteString(borderStyle.Render("╚" + strings.Repeat("═", modalW-2) + "╝"))
+
+	content.WriteString(StyleFrameBorder.Render("├"+strings.Repeat("─", leftW+2)+"┴"+strings.Repeat("─", rightW+2)+"┤") + "\n")
+	hint := ShortKeyLabel(ActionPanelNext) + " values · " + ShortKeyLabel(ActionPanelOpen) + " add " + stat.Key + "=… · ! exclude"
+	hint = PadRight(TruncateVisual(hint, modalW-4), modalW-4)
+	content.WriteString(StyleFrameBorder.Render("│") + " " + StyleEmpty.Render(hint) + " " + StyleFrameBorder.Render("│") + "\n")
+	content.WriteString(StyleFrameBorder.Render("╰"+strings.Repeat("─", modalW-2)+"╯"))
 
 	modalLines := strings.Split(content.String(), "\n")
-	modalH := len(modalLines)
-	padTop := (height - modalH) / 2
+	padTop := (height - len(modalLines)) / 2
 	if padTop < 0 {
 		padTop = 0
 	}
@@ -1681,62 +1949,421 @@ func RenderQuitConfirm(workspaceCount, height, width int) string {
 	return result.String()
 }
 
-func RenderFooter(mode int, statusMsg string, selectionCount, width int) string {
-	var hintParts []string
+func compareBadge(item signal.CompareItem) string {
+	switch item.Status {
+	case signal.CompareNew:
+		return StyleLevelError.Copy().Padding(0).Render(PadCenter("NEW", 6))
+	case signal.CompareGone:
+		return StyleDetailDim.Render(PadCenter("GONE", 6))
+	case signal.CompareLevel:
+		return LevelStyle(item.Level()).Copy().Padding(0).Render(PadCenter("LEVEL", 6))
+	case signal.CompareMore:
+		return StyleBarAccent.Render(PadLeft("↑"+signal.FormatNumber(math.Round(item.Ratio*10)/10)+"x", 6))
+	}
+	return StyleStatus.Render(PadLeft("↓"+signal.FormatNumber(math.Round(item.Ratio*100)/100)+"x", 6))
+}
 
-	if selectionCount > 0 && app.Mode(mode) == app.ModeList {
-		hintParts = append(hintParts,
-			StyleBarAccent.Render("●")+StyleBarText.Render(" "+Itoa(selectionCount)),
-			StyleBarAccent.Render("c")+StyleBarText.Render(" copy"),
-			StyleBarAccent.Render("d")+StyleBarText.Render(" delete"),
-			StyleBarAccent.Render("S")+StyleBarText.Render(" clear"),
-			StyleBarAccent.Render("s")+StyleBarText.Render(" +/-"))
-	} else {
-		switch app.Mode(mode) {
-		case app.ModeFilter:
-			hintParts = append(hintParts,
-				StyleBarAccent.Render("Tab")+StyleBarText.Render(" level"),
-				StyleBarAccent.Render("Enter")+StyleBarText.Render(" apply"),
-				StyleBarAccent.Render("ESC")+StyleBarText.Render(" cancel"))
-		case app.ModeDetail:
-			hintParts = append(hintParts,
-				StyleBarAccent.Render("z")+StyleBarText.Render(" maximize"),
-				StyleBarAccent.Render("^L")+StyleBarText.Render(" lookup"),
-				StyleBarAccent.Render("1-4")+StyleBarText.Render(" signal"),
-				StyleBarAccent.Render("c")+StyleBarText.Render(" copy"),
-				StyleBarAccent.Render("ESC")+StyleBarText.Render(" back"))
-		case app.ModeNotes:
-			hintParts = append(hintParts,
-				StyleBarAccent.Render("!")+StyleBarText.Render(" crit"),
-				StyleBarAccent.Render("?")+StyleBarText.Render(" unsure"),
-				StyleBarAccent.Render("Enter")+StyleBarText.Render(" save"),
-				StyleBarAccent.Render("ESC")+StyleBarText.Render(" cancel"))
-		case app.ModeLookup:
-			hintParts = append(hintParts,
-				StyleBarAccent.Render("↑↓")+StyleBarText.Render(" select"),
-				StyleBarAccent.Render("Enter")+StyleBarText.Render(" copy"),
-				StyleBarAccent.Render("ESC")+StyleBarText.Render(" close"))
-		case app.ModeSignal:
-			hintParts = append(hintParts,
-				StyleBarAccent.Render("c")+StyleBarText.Render(" copy"),
-				StyleBarAccent.Render("ESC")+StyleBarText.Render(" close"))
-		default:
-			hintParts = append(hintParts,
-				StyleBarAccent.Render("s")+StyleBarText.Render(" select"),
-				StyleBarAccent.Render("/")+StyleBarText.Render(" filter"),
-				StyleBarAccent.Render("N")+StyleBarText.Render(" note"),
-				StyleBarAccent.Render("?")+StyleBarText.Render(" help"))
-		}
+func RenderCompareModal(s *app.State, baseName string, height, width int) string {
+	result := s.Compare
+	if result == nil {
+		return ""
+	}
+	modalW := 96
+	if modalW > width-4 {
+		modalW = width - 4
 	}
+	innerW := modalW - 4
+	leftW := (modalW - 7) / 2
+	rightW := modalW - 7 - leftW
 
-	sep := StyleBarDim.Render(" · ")
-	hints := strings.Join(hintParts, sep)
+	maxRows := height - 14
+	if maxRows > 12 {
+		maxRows = 12
+	}
+	if maxRows < 1 {
+		maxRows = 1
+	}
 
-	credit := StyleBarDim.Render("by ") + StyleBarText.Render("kalayciburak")
+	var content strings.Builder
 
-	leftW := lipgloss.Width(hints)
-	rightW := lipgloss.Width(credit)
-	padding := width - leftW - rightW
+	headerText := " COMPARE " + Truncate(baseName, 30) + " → " + Truncate(s.FileName, 30) + " "
+	headerPadTotal := modalW - 2 - lipgloss.Width(headerText)
+	leftPad := headerPadTotal / 2
+	rightPad := headerPadTotal - leftPad
+	if leftPad < 0 {
+		leftPad = 0
+	}
+	if rightPad < 0 {
+		rightPad = 0
+	}
+	content.WriteString(StyleFrameBorder.Render("╭"+strings.Repeat("─", leftPad)) + StyleDetailHeader.Render(headerText) + StyleFrameBorder.Render(strings.Repeat("─", rightPad)+"╮") + "\n")
+
+	row := func(line string) {
+		pad := innerW - lipgloss.Width(line)
+		if pad < 0 {
+			pad = 0
+		}
+		content.WriteString(StyleFrameBorder.Render("│") + " " + line + strings.Repeat(" ", pad) + " " + StyleFrameBorder.Render("│") + "\n")
+	}
+
+	summary := Itoa(result.BaseTotal) + " → " + Itoa(result.CurrentTotal) + " lines · " +
+		Itoa(len(result.Items)) + " changed · " + Itoa(result.Unchanged) + " unchanged templates"
+	row(StyleDetailDim.Render(TruncateVisual(summary, innerW)))
+	countsW := 16
+	head := PadRight("", 7) + PadRight("template", innerW-7-countsW) + PadLeft("base → now", countsW)
+	row(StyleDetailLabel.Render(head))
+	content.WriteString(StyleFrameBorder.Render("├"+strings.Repeat("─", modalW-2)+"┤") + "\n")
+
+	if len(result.Items) == 0 {
+		row(StyleStatus.Render("✓ No new, missing or changed templates"))
+		for i := 1; i < maxRows; i++ {
+			row("")
+		}
+	} else {
+		start := 0
+		if s.CompareCursor >= maxRows {
+			start = s.CompareCursor - maxRows + 1
+		}
+		for r := 0; r < maxRows; r++ {
+			i := start + r
+			if i >= len(result.Items) {
+				row("")
+				continue
+			}
+			item := result.Items[i]
+			counts := PadLeft(Itoa(item.Base.Count)+" → "+Itoa(item.Current.Count), countsW)
+			text := PadRight(TruncateVisual(item.Template, innerW-8-countsW), innerW-7-countsW) + counts
+			if i == s.CompareCursor {
+				text = StyleLookupSelected.Render(text)
+			} else {
+				text = StyleLookupResult.Render(text)
+			}
+			row(compareBadge(item) + " " + text)
+		}
+	}
+
+	content.WriteString(StyleFrameBorder.Render("├"+strings.Repeat("─", leftW+2)+"┬"+strings.Repeat("─", rightW+2)+"┤") + "\n")
+	var item signal.CompareItem
+	if s.CompareCursor < len(result.Items) {
+		item = result.Items[s.CompareCursor]
+	}
+	pane := func(title string, side signal.CompareSide, w int) []string {
+		if side.Count > 0 {
+			title += " " + Itoa(side.Count) + "× " + side.Level.String()
+		}
+		lines := []string{StyleDetailLabel.Render(PadRight(TruncateVisual(title, w), w))}
+		for i := 0; i < 3; i++ {
+			text := ""
+			if i < len(side.Examples) {
+				text = side.Examples[i]
+			} else if i == 0 && len(result.Items) > 0 {
+				text = "(none)"
+			}
+			lines = append(lines, StyleMessage.Render(PadRight(TruncateVisual(text, w), w)))
+		}
+		return lines
+	}
+	left := pane("BASELINE", item.Base, leftW)
+	right := pane("CURRENT", item.Current, rightW)
+	for i := range left {
+		content.WriteString(StyleFrameBorder.Render("│") + " " + left[i] + " " + StyleFrameBorder.Render("│") + " " + right[i] + " " + StyleFrameBorder.Render("│") + "\n")
+	}
+	content.WriteString(StyleFrameBorder.Render("├"+strings.Repeat("─", leftW+2)+"┴"+strings.Repeat("─", rightW+2)+"┤") + "\n")
+
+	hint := ShortKeyLabel(ActionPanelOpen) + " jump to example · " + ShortKeyLabel(ActionPanelNext) + " other baseline · " + ShortKeyLabel(ActionCopy) + " copy · ESC close"
+	hint = PadRight(TruncateVisual(hint, innerW), innerW)
+	content.WriteString(StyleFrameBorder.Render("│") + " " + StyleEmpty.Render(hint) + " " + StyleFrameBorder.Render("│") + "\n")
+	content.WriteString(StyleFrameBorder.Render("╰"+strings.Repeat("─", modalW-2)+"╯"))
+
+	modalLines := strings.Split(content.String(), "\n")
+	padTop := (height - len(modalLines)) / 2
+	if padTop < 0 {
+		padTop = 0
+	}
+	padLeft := (width - modalW) / 2
+	if padLeft < 0 {
+		padLeft = 0
+	}
+
+	var out strings.Builder
+	for i := 0; i < padTop; i++ {
+		out.WriteString(strings.Repeat(" ", width) + "\n")
+	}
+	for _, line := range modalLines {
+		out.WriteString(strings.Repeat(" ", padLeft) + line + "\n")
+	}
+
+	return out.String()
+}
+
+func RenderColumnPicker(s *app.State, height, width int) string {
+	modalW := 50
+	if modalW > width-4 {
+		modalW = width - 4
+	}
+	innerW := modalW - 4
+
+	maxRows := height - 6
+	if maxRows > 12 {
+		maxRows = 12
+	}
+	if maxRows < 1 {
+		maxRows = 1
+	}
+
+	var content strings.Builder
+
+	headerText := " TABLE COLUMNS "
+	headerPadTotal := modalW - 2 - len(headerText)
+	leftPad := headerPadTotal / 2
+	rightPad := headerPadTotal - leftPad
+	if leftPad < 0 {
+		leftPad = 0
+	}
+	if rightPad < 0 {
+		rightPad = 0
+	}
+	content.WriteString(StyleFrameBorder.Render("╭"+strings.Repeat("─", leftPad)) + StyleDetailHeader.Render(headerText) + StyleFrameBorder.Render(strings.Repeat("─", rightPad)+"╮") + "\n")
+
+	widths := make(map[string]int, len(s.Columns))
+	for _, col := range ListColumns(s) {
+		widths[col.Key] = col.Width
+	}
+	total := len(s.Filtered)
+
+	start := 0
+	if s.ColumnCursor >= maxRows {
+		start = s.ColumnCursor - maxRows + 1
+	}
+	end := start + maxRows
+	if end > len(s.ColumnChoices) {
+		end = len(s.ColumnChoices)
+	}
+
+	for i := start; i < end; i++ {
+		choice := s.ColumnChoices[i]
+		mark := "[ ] "
+		right := ""
+		if w, ok := widths[choice.Key]; ok {
+			mark = "[x] "
+			right = "w" + Itoa(w) + "  "
+		}
+		if total > 0 {
+			right += PadLeft(Itoa(choice.Count*100/total)+"%", 4)
+		}
+		keyW := innerW - lipgloss.Width(mark) - lipgloss.Width(right) - 1
+		line := mark + PadRight(TruncateVisual(choice.Key, keyW), keyW) + " " + right
+		if i == s.ColumnCursor {
+			content.WriteString(StyleFrameBorder.Render("│") + " " + StyleLookupSelected.Render(line) + " " + StyleFrameBorder.Render("│") + "\n")
+		} else {
+			content.WriteString(StyleFrameBorder.Render("│") + " " + StyleLookupResult.Render(line) + " " + StyleFrameBorder.Render("│") + "\n")
+		}
+	}
+
+	content.WriteString(StyleFrameBorder.Render("├"+strings.Repeat("─", modalW-2)+"┤") + "\n")
+	hint := "Enter toggle · " + ShortKeyLabel(ActionScrollLeft, ActionScrollRight) + " resize · " + ShortKeyLabel(ActionClear) + " clear"
+	hint = PadRight(TruncateVisual(hint, innerW), innerW)
+	content.WriteString(StyleFrameBorder.Render("│") + " " + StyleEmpty.Render(hint) + " " + StyleFrameBorder.Render("│") + "\n")
+	content.WriteString(StyleFrameBorder.Render("╰"+strings.Repeat("─", modalW-2)+"╯"))
+
+	modalLines := strings.Split(content.String(), "\n")
+	padTop := (height - len(modalLines)) / 2
+	if padTop < 0 {
+		padTop = 0
+	}
+	padLeft := (width - modalW) / 2
+	if padLeft < 0 {
+		padLeft = 0
+	}
+
+	var result strings.Builder
+	for i := 0; i < padTop; i++ {
+		result.WriteString(strings.Repeat(" ", width) + "\n")
+	}
+	for _, line := range modalLines {
+		result.WriteString(strings.Repeat(" ", padLeft) + line + "\n")
+	}
+
+	return result.String()
+}
+
+func RenderQuitConfirm(workspaceCount, height, width int) string {
+	modalW := 44
+	if modalW > width-4 {
+		modalW = width - 4
+	}
+
+	borderStyle := lipgloss.NewStyle().Foreground(ColorWarn)
+
+	var content strings.Builder
+
+	headerText := " ⚠ QUIT CONFIRMATION "
+	headerStyled := StyleLevelWarn.Bold(true).Render(headerText)
+	headerW := lipgloss.Width(headerStyled)
+	headerPadTotal := modalW - 2 - headerW
+	leftPad := headerPadTotal / 2
+	rightPad := headerPadTotal - leftPad
+	if leftPad < 0 {
+		leftPad = 0
+	}
+	if rightPad < 0 {
+		rightPad = 0
+	}
+	content.WriteString(borderStyle.Render("╔"+strings.Repeat("═", leftPad)) + headerStyled + borderStyle.Render(strings.Repeat("═", rightPad)+"╗") + "\n")
+
+	emptyLine := borderStyle.Render("║") + strings.Repeat(" ", modalW-2) + borderStyle.Render("║") + "\n"
+	content.WriteString(emptyLine)
+
+	textBright := lipgloss.NewStyle().Foreground(ColorTextBright)
+	countStyled := StyleBarAccent.Bold(true).Render(Itoa(workspaceCount))
+	msgPre := "You have "
+	msgPost := " open workspaces."
+	msgLine := textBright.Render(msgPre) + countStyled + textBright.Render(msgPost)
+	msgLineW := lipgloss.Width(msgLine)
+	msgPad := (modalW - 2 - msgLineW) / 2
+	if msgPad < 0 {
+		msgPad = 0
+	}
+	content.WriteString(borderStyle.Render("║") + strings.Repeat(" ", msgPad) + msgLine + strings.Repeat(" ", modalW-2-msgPad-msgLineW) + borderStyle.Render("║") + "\n")
+
+	msg2 := "Are you sure you want to quit?"
+	msg2Styled := textBright.Bold(true).Render(msg2)
+	msg2W := lipgloss.Width(msg2Styled)
+	msg2Pad := (modalW - 2 - msg2W) / 2
+	if msg2Pad < 0 {
+		msg2Pad = 0
+	}
+	content.WriteString(borderStyle.Render("║") + strings.Repeat(" ", msg2Pad) + msg2Styled + strings.Repeat(" ", modalW-2-msg2Pad-msg2W) + borderStyle.Render("║") + "\n")
+
+	content.WriteString(emptyLine)
+	content.WriteString(borderStyle.Render("╠" + strings.Repeat("═", modalW-2) + "╣") + "\n")
+
+	yStyle := lipgloss.NewStyle().Background(ColorError).Foreground(ColorBg).Bold(true)
+	nStyle := lipgloss.NewStyle().Background(ColorSuccess).Foreground(ColorBg).Bold(true)
+	hints := yStyle.Render(" y ") + textBright.Render(" quit  ") +
+		nStyle.Render(" n/ESC ") + textBright.Render(" cancel")
+	hintsW := lipgloss.Width(hints)
+	hintsPad := (modalW - 2 - hintsW) / 2
+	if hintsPad < 0 {
+		hintsPad = 0
+	}
+	content.WriteString(borderStyle.Render("║") + strings.Repeat(" ", hintsPad) + hints + strings.Repeat(" ", modalW-2-hintsPad-hintsW) + borderStyle.Render("║") + "\n")
+	content.WriteString(borderStyle.Render("╚" + strings.Repeat("═", modalW-2) + "╝"))
+
+	modalLines := strings.Split(content.String(), "\n")
+	modalH := len(modalLines)
+	padTop := (height - modalH) / 2
+	if padTop < 0 {
+		padTop = 0
+	}
+	padLeft := (width - modalW) / 2
+	if padLeft < 0 {
+		padLeft = 0
+	}
+
+	var result strings.Builder
+	for i := 0; i < padTop; i++ {
+		result.WriteString(strings.Repeat(" ", width) + "\n")
+	}
+	for _, line := range modalLines {
+		result.WriteString(strings.Repeat(" ", padLeft) + line + "\n")
+	}
+
+	return result.String()
+}
+
+func RenderFooter(mode int, statusMsg string, selectionCount, width int) string {
+	var hintParts []string
+
+	if selectionCount > 0 && app.Mode(mode) == app.ModeList {
+		hintParts = append(hintParts,
+			StyleBarAccent.Render("●")+StyleBarText.Render(" "+Itoa(selectionCount)),
+			StyleBarAccent.Render(ShortKeyLabel(ActionCopy))+StyleBarText.Render(" copy"),
+			StyleBarAccent.Render(ShortKeyLabel(ActionDelete))+StyleBarText.Render(" delete"),
+			StyleBarAccent.Render(ShortKeyLabel(ActionSelectAll))+StyleBarText.Render(" clear"),
+			StyleBarAccent.Render(ShortKeyLabel(ActionSelect))+StyleBarText.Render(" +/-"))
+	} else {
+		switch app.Mode(mode) {
+		case app.ModeFilter:
+			hintParts = append(hintParts,
+				StyleBarAccent.Render(ShortKeyLabel(ActionLevelCycle))+StyleBarText.Render(" level"),
+				StyleBarAccent.Render(ShortKeyLabel(ActionLevelMode))+StyleBarText.Render(" mode"),
+				StyleBarAccent.Render("Enter")+StyleBarText.Render(" apply"),
+				StyleBarAccent.Render("ESC")+StyleBarText.Render(" cancel"))
+		case app.ModeDetail:
+			hintParts = append(hintParts,
+				StyleBarAccent.Render(ShortKeyLabel(ActionMaximize))+StyleBarText.Render(" maximize"),
+				StyleBarAccent.Render(ShortKeyLabel(ActionLookup))+StyleBarText.Render(" lookup"),
+				StyleBarAccent.Render(ShortKeyLabel(ActionSignalFrequency)+"-"+ShortKeyLabel(ActionSignalAnomalies))+StyleBarText.Render(" signal"),
+				StyleBarAccent.Render(ShortKeyLabel(ActionCopy))+StyleBarText.Render(" copy"),
+				StyleBarAccent.Render("ESC")+StyleBarText.Render(" back"))
+		case app.ModeNotes:
+			hintParts = append(hintParts,
+				StyleBarAccent.Render("!")+StyleBarText.Render(" crit"),
+				StyleBarAccent.Render("?")+StyleBarText.Render(" unsure"),
+				StyleBarAccent.Render("Enter")+StyleBarText.Render(" save"),
+				StyleBarAccent.Render("ESC")+StyleBarText.Render(" cancel"))
+		case app.ModeLookup:
+			hintParts = append(hintParts,
+				StyleBarAccent.Render("↑↓")+StyleBarText.Render(" select"),
+				StyleBarAccent.Render("Enter")+StyleBarText.Render(" copy"),
+				StyleBarAccent.Render("ESC")+StyleBarText.Render(" close"))
+		case app.ModeSignal:
+			hintParts = append(hintParts,
+				StyleBarAccent.Render(ShortKeyLabel(ActionCopy))+StyleBarText.Render(" copy"),
+				StyleBarAccent.Render("ESC")+StyleBarText.Render(" close"))
+		case app.ModeFields:
+			hintParts = append(hintParts,
+				StyleBarAccent.Render(ShortKeyLabel(ActionDown, ActionUp))+StyleBarText.Render(" nav"),
+				StyleBarAccent.Render(ShortKeyLabel(ActionPanelNext))+StyleBarText.Render(" values"),
+				StyleBarAccent.Render(ShortKeyLabel(ActionPanelOpen))+StyleBarText.Render(" filter"),
+				StyleBarAccent.Render("!")+StyleBarText.Render(" exclude"),
+				StyleBarAccent.Render("ESC")+StyleBarText.Render(" close"))
+		case app.ModeColumns:
+			hintParts = append(hintParts,
+				StyleBarAccent.Render(ShortKeyLabel(ActionDown, ActionUp))+StyleBarText.Render(" nav"),
+				StyleBarAccent.Render("Enter")+StyleBarText.Render(" toggle"),
+				StyleBarAccent.Render(ShortKeyLabel(ActionScrollLeft, ActionScrollRight))+StyleBarText.Render(" resize"),
+				StyleBarAccent.Render(ShortKeyLabel(ActionClear))+StyleBarText.Render(" clear"),
+				StyleBarAccent.Render("ESC")+StyleBarText.Render(" close"))
+		case app.ModeCompare:
+			hintParts = append(hintParts,
+				StyleBarAccent.Render(ShortKeyLabel(ActionDown, ActionUp))+StyleBarText.Render(" nav"),
+				StyleBarAccent.Render(ShortKeyLabel(ActionPanelOpen))+StyleBarText.Render(" jump"),
+				StyleBarAccent.Render(ShortKeyLabel(ActionPanelNext))+StyleBarText.Render(" baseline"),
+				StyleBarAccent.Render(ShortKeyLabel(ActionCopy))+StyleBarText.Render(" copy"),
+				StyleBarAccent.Render("ESC")+StyleBarText.Render(" close"))
+		case app.ModeDiff:
+			hintParts = append(hintParts,
+				StyleBarAccent.Render(ShortKeyLabel(ActionDown, ActionUp))+StyleBarText.Render(" scroll"),
+				StyleBarAccent.Render("n/N")+StyleBarText.Render(" hunk"),
+				StyleBarAccent.Render(ShortKeyLabel(ActionPanelOpen))+StyleBarText.Render(" jump"),
+				StyleBarAccent.Render(ShortKeyLabel(ActionPanelNext))+StyleBarText.Render(" other"),
+				StyleBarAccent.Render("ESC")+StyleBarText.Render(" close"))
+		case app.ModeCorrelation:
+			hintParts = append(hintParts,
+				StyleBarAccent.Render(ShortKeyLabel(ActionDown, ActionUp))+StyleBarText.Render(" nav"),
+				StyleBarAccent.Render(ShortKeyLabel(ActionPanelOpen))+StyleBarText.Render(" jump"),
+				StyleBarAccent.Render(ShortKeyLabel(ActionPanelNext))+StyleBarText.Render(" next id"),
+				StyleBarAccent.Render(ShortKeyLabel(ActionCopy))+StyleBarText.Render(" copy"),
+				StyleBarAccent.Render("ESC")+StyleBarText.Render(" close"))
+		default:
+			hintParts = append(hintParts,
+				StyleBarAccent.Render(ShortKeyLabel(ActionSelect))+StyleBarText.Render(" select"),
+				StyleBarAccent.Render(ShortKeyLabel(ActionFilter))+StyleBarText.Render(" filter"),
+				StyleBarAccent.Render(ShortKeyLabel(ActionNoteEdit))+StyleBarText.Render(" note"),
+				StyleBarAccent.Render(ShortKeyLabel(ActionHelp))+StyleBarText.Render(" help"))
+		}
+	}
+
+	sep := StyleBarDim.Render(" · ")
+	hints := strings.Join(hintParts, sep)
+
+	credit := StyleBarDim.Render("by ") + StyleBarText.Render("kalayciburak")
+
+	leftW := lipgloss.Width(hints)
+	rightW := lipgloss.Width(credit)
+	padding := width - leftW - rightW
 	if padding < 1 {
 		padding = 1
 	}
@@ -1750,12 +2377,197 @@ func RenderFooter(mode int, statusMsg string, selectionCount, width int) string
 	return content
 }
 
-func RenderSignalModal(result *signal.SignalResult, height, width int) string {
+func plural(n int, what string) string {
+	if n == 1 {
+		return "1 " + what
+	}
+	return Itoa(n) + " " + what + "s"
+}
+
+func LaneStyle(lane int) lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(LaneColors[lane%len(LaneColors)]).Bold(true)
+}
+
+func RenderDiff(s *app.State, leftName, rightName string, height, width int) string {
+	var lines []string
+
+	removed, added, changed := 0, 0, 0
+	for _, row := range s.DiffRows {
+		switch row.Kind {
+		case diff.Removed:
+			removed++
+		case diff.Added:
+			added++
+		case diff.Changed:
+			changed++
+		}
+	}
+
+	header := StyleDetailHeader.Render(" DIFF ") + " " + StyleDiffRemoved.Render(leftName) +
+		StyleDetailDim.Render(" → ") + StyleDiffAdded.Render(rightName)
+	lines = append(lines, header)
+	summary := plural(len(s.DiffHunks), "hunk") + " · " + Itoa(removed) + " removed · " + Itoa(added) + " added · " + Itoa(changed) + " changed"
+	if hunk := s.DiffHunk(); hunk >= 0 {
+		summary += " · hunk " + Itoa(hunk+1) + "/" + Itoa(len(s.DiffHunks))
+	}
+	lines = append(lines, " "+StyleDetailDim.Render(summary))
+	lines = append(lines, StyleHunkSeparator.Render(strings.Repeat("─", width)))
+
+	listH := height - len(lines)
+	if listH < 1 {
+		listH = 1
+	}
+	start := s.DiffScroll - listH/3
+	if start > len(s.DiffRows)-listH {
+		start = len(s.DiffRows) - listH
+	}
+	if start < 0 {
+		start = 0
+	}
+
+	colW := (width - 3) / 2
+	side := func(entries []logx.Entry, idx int, marker string, style lipgloss.Style, w int) string {
+		if idx < 0 {
+			return strings.Repeat(" ", w)
+		}
+		e := entries[idx]
+		num := PadLeft(Itoa(idx+1), 6) + " "
+		level := LevelStyle(e.Level).Copy().Padding(0).Render(PadRight(e.Level.String(), 5))
+		if e.Level == logx.LevelUnknown {
+			level = strings.Repeat(" ", 5)
+		}
+		msgW := w - len(num) - 8
+		msg := style.Render(PadRight(TruncateVisual(e.Message, msgW), msgW))
+		return StyleLineNum.Render(num) + style.Render(marker) + " " + level + " " + msg
+	}
+
+	for i := start; i < len(s.DiffRows) && i-start < listH; i++ {
+		row := s.DiffRows[i]
+		var left, right string
+		switch row.Kind {
+		case diff.Same:
+			left = side(s.DiffLeft, row.A, " ", StyleContextMessage, colW)
+			right = side(s.DiffRight, row.B, " ", StyleContextMessage, colW)
+		case diff.Changed:
+			left = side(s.DiffLeft, row.A, "~", StyleDiffChanged, colW)
+			right = side(s.DiffRight, row.B, "~", StyleDiffChanged, colW)
+		case diff.Removed:
+			left = side(s.DiffLeft, row.A, "-", StyleDiffRemoved, colW)
+			right = side(s.DiffRight, row.B, " ", StyleDiffRemoved, colW)
+		case diff.Added:
+			left = side(s.DiffLeft, row.A, " ", StyleDiffAdded, colW)
+			right = side(s.DiffRight, row.B, "+", StyleDiffAdded, colW)
+		}
+		marker := " "
+		if i == s.DiffScroll {
+			marker = StyleCursorIndicator.Render(">")
+		}
+		lines = append(lines, marker+left+StyleHunkSeparator.Render(" │")+right)
+	}
+
+	if len(s.DiffRows) == 0 {
+		lines = append(lines, StyleEmpty.Render(" Both workspaces are empty"))
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines[:height], "\n")
+}
+
+func RenderCorrelation(r *signal.CorrelationResult, cursor int, showWorkspace bool, height, width int) string {
+	var lines []string
+	if r == nil {
+		return strings.Repeat("\n", height-1)
+	}
+
+	lanes := make(map[string]int, len(r.Services))
+	for i, svc := range r.Services {
+		lanes[svc] = i
+	}
+
+	header := StyleDetailHeader.Render(" CORRELATION ") + " " +
+		StyleDetailLabel.Render(r.Key) + StyleDetailDim.Render(" = ") + StyleDetailValue.Render(r.Value)
+	lines = append(lines, header)
+
+	summary := plural(len(r.Events), "event") + " · " + plural(len(r.Services), "service")
+	if r.HasTime {
+		summary += " · elapsed " + signal.FormatDuration(r.Elapsed)
+	}
+	lines = append(lines, " "+StyleDetailDim.Render(summary))
+
+	var legend []string
+	for i, svc := range r.Services {
+		legend = append(legend, LaneStyle(i).Render("●")+" "+StyleMessage.Render(svc))
+	}
+	lines = append(lines, " "+strings.Join(legend, "  "))
+	lines = append(lines, StyleHunkSeparator.Render(strings.Repeat("─", width)))
+
+	listH := height - len(lines)
+	if listH < 1 {
+		listH = 1
+	}
+	start := 0
+	if cursor >= listH {
+		start = cursor - listH + 1
+	}
+
+	for i := start; i < len(r.Events) && i-start < listH; i++ {
+		ev := r.Events[i]
+		selected := i == cursor
+
+		offset := "?"
+		if d, ok := r.Offset(ev); ok {
+			offset = "+" + signal.FormatDuration(d)
+		}
+		marker := " "
+		if selected {
+			marker = StyleCursorIndicator.Render(">")
+		}
+		row := marker + " " + StyleTimestamp.Render(PadLeft(offset, 9)) + "  "
+
+		lane := lanes[ev.Service]
+		for l := range r.Services {
+			if l == lane {
+				row += LaneStyle(l).Render("●") + " "
+			} else {
+				row += StyleHunkSeparator.Render("│") + " "
+			}
+		}
+
+		if showWorkspace {
+			row += StyleDetailDim.Render("W"+Itoa(ev.Workspace+1)) + " "
+		}
+		row += LaneStyle(lane).Render(PadRight(Truncate(ev.Service, 12), 12)) + " "
+		row += LevelStyle(ev.Entry.Level).Render(PadCenter(ev.Entry.Level.String(), 7)) + " "
+
+		msgW := width - lipgloss.Width(row)
+		if msgW < 10 {
+			msgW = 10
+		}
+		msg := Truncate(ev.Entry.Message, msgW)
+		if selected {
+			row += StyleLineNumSelected.Render(msg)
+		} else {
+			row += StyleMessage.Render(msg)
+		}
+		lines = append(lines, row)
+	}
+
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines[:height], "\n")
+}
+
+func RenderSignalModal(result *signal.SignalResult, cursor, height, width int) string {
 	if result == nil {
 		return ""
 	}
 
 	modalW := 55
+	if result.Type == signal.SignalStats || result.Type == signal.SignalRate || result.Type == signal.SignalBurst || result.Type == signal.SignalAnomaly || result.Type == signal.SignalLifetime {
+		modalW = 72
+	}
 	if modalW > width-4 {
 		modalW = width - 4
 	}
@@ -1767,7 +2579,7 @@ func RenderSignalModal(result *signal.SignalResult, height, width int) string {
 	var content strings.Builder
 
 	headerText := " SIGNAL BOOSTER — " + result.Title + " "
-	headerPadTotal := modalW - 2 - len(headerText)
+	headerPadTotal := modalW - 2 - lipgloss.Width(headerText)
 	leftPad := headerPadTotal / 2
 	rightPad := headerPadTotal - leftPad
 	if leftPad < 0 {
@@ -1783,13 +2595,19 @@ func RenderSignalModal(result *signal.SignalResult, height, width int) string {
 	var contentLines []string
 	switch result.Type {
 	case signal.SignalFrequency:
-		contentLines = renderFrequencyContent(result.Frequency, innerW)
+		contentLines = renderFrequencyContent(result.Frequency, cursor, innerW)
 	case signal.SignalLifetime:
-		contentLines = renderLifetimeContent(result.Lifetime, innerW)
+		contentLines = renderLifetimeContent(result.Lifetime, cursor, innerW)
 	case signal.SignalBurst:
-		contentLines = renderBurstContent(result.Burst, innerW)
+		contentLines = renderBurstContent(result.Burst, cursor, innerW)
 	case signal.SignalDiversity:
 		contentLines = renderDiversityContent(result.Diversity, innerW)
+	case signal.SignalStats:
+		contentLines = renderStatsContent(result.Stats, innerW)
+	case signal.SignalRate:
+		contentLines = renderRateContent(result.Rate, innerW)
+	case signal.SignalAnomaly:
+		contentLines = renderAnomalyContent(result.Anomalies, cursor, innerW)
 	}
 
 	for _, line := range contentLines {
@@ -1806,12 +2624,46 @@ func RenderSignalModal(result *signal.SignalResult, height, width int) string {
 	content.WriteString(StyleFrameBorder.Render("├" + strings.Repeat("─", modalW-2) + "┤") + "\n")
 
 	var hints string
-	if result.Type == signal.SignalLifetime || result.Type == signal.SignalBurst {
-		hints = StyleHelpKey.Render("j/k") + StyleFooter.Render(" nav  ") +
-			StyleHelpKey.Render("c") + StyleFooter.Render(" copy  ") +
+	if result.Type == signal.SignalRate {
+		hints = StyleHelpKey.Render(ShortKeyLabel(ActionDown, ActionUp)) + StyleFooter.Render(" nav  ") +
+			StyleHelpKey.Render(ShortKeyLabel(ActionPanelNext)) + StyleFooter.Render(" message/all  ") +
+			StyleHelpKey.Render(ShortKeyLabel(ActionCopy)) + StyleFooter.Render(" copy  ") +
+			StyleHelpKey.Render("ESC") + StyleFooter.Render(" close")
+	} else if result.Type == signal.SignalBurst && result.Burst != nil && len(result.Burst.Bursts) > 0 {
+		hints = StyleHelpKey.Render(ShortKeyLabel(ActionDown, ActionUp)) + StyleFooter.Render(" nav  ") +
+			StyleHelpKey.Render(ShortKeyLabel(ActionPanelNext)) + StyleFooter.Render(" burst  ") +
+			StyleHelpKey.Render(ShortKeyLabel(ActionPanelOpen)) + StyleFooter.Render(" jump  ") +
+			StyleHelpKey.Render(ShortKeyLabel(ActionCopy)) + StyleFooter.Render(" copy  ") +
+			StyleHelpKey.Render("ESC") + StyleFooter.Render(" close")
+	} else if result.Type == signal.SignalLifetime && result.Lifetime != nil && result.Lifetime.FirstEntry >= 0 {
+		hints = StyleHelpKey.Render(ShortKeyLabel(ActionDown, ActionUp)) + StyleFooter.Render(" nav  ")
+		if !result.Lifetime.IsSingle {
+			hints += StyleHelpKey.Render(ShortKeyLabel(ActionPanelNext)) + StyleFooter.Render(" first/last  ")
+		}
+		hints += StyleHelpKey.Render(ShortKeyLabel(ActionPanelOpen)) + StyleFooter.Render(" jump  ") +
+			StyleHelpKey.Render(ShortKeyLabel(ActionCopy)) + StyleFooter.Render(" copy  ") +
+			StyleHelpKey.Render("ESC") + StyleFooter.Render(" close")
+	} else if result.Type == signal.SignalLifetime || result.Type == signal.SignalBurst {
+		hints = StyleHelpKey.Render(ShortKeyLabel(ActionDown, ActionUp)) + StyleFooter.Render(" nav  ") +
+			StyleHelpKey.Render(ShortKeyLabel(ActionCopy)) + StyleFooter.Render(" copy  ") +
+			StyleHelpKey.Render("ESC") + StyleFooter.Render(" close")
+	} else if result.Type == signal.SignalFrequency && len(result.Frequency) > 0 {
+		hints = StyleHelpKey.Render(ShortKeyLabel(ActionDown, ActionUp)) + StyleFooter.Render(" nav  ") +
+			StyleHelpKey.Render(ShortKeyLabel(ActionPanelOpen)) + StyleFooter.Render(" filter  ") +
+			StyleHelpKey.Render(ShortKeyLabel(ActionCopy)) + StyleFooter.Render(" copy  ") +
+			StyleHelpKey.Render("ESC") + StyleFooter.Render(" close")
+	} else if result.Type == signal.SignalAnomaly && result.Anomalies != nil && len(result.Anomalies.Items) > 0 {
+		hints = StyleHelpKey.Render(ShortKeyLabel(ActionDown, ActionUp)) + StyleFooter.Render(" nav  ") +
+			StyleHelpKey.Render(ShortKeyLabel(ActionPanelOpen)) + StyleFooter.Render(" jump  ") +
+			StyleHelpKey.Render(ShortKeyLabel(ActionCopy)) + StyleFooter.Render(" copy  ") +
+			StyleHelpKey.Render("ESC") + StyleFooter.Render(" close")
+	} else if result.Type == signal.SignalStats {
+		hints = StyleHelpKey.Render(ShortKeyLabel(ActionPanelNext)) + StyleFooter.Render(" field  ") +
+			StyleHelpKey.Render(ShortKeyLabel(ActionPanelPrev)) + StyleFooter.Render(" group by  ") +
+			StyleHelpKey.Render(ShortKeyLabel(ActionCopy)) + StyleFooter.Render(" copy  ") +
 			StyleHelpKey.Render("ESC") + StyleFooter.Render(" close")
 	} else {
-		hints = StyleHelpKey.Render("c") + StyleFooter.Render(" copy  ") +
+		hints = StyleHelpKey.Render(ShortKeyLabel(ActionCopy)) + StyleFooter.Render(" copy  ") +
 			StyleHelpKey.Render("ESC") + StyleFooter.Render(" close")
 	}
 	hintsW := lipgloss.Width(hints)
@@ -1844,7 +2696,7 @@ func RenderSignalModal(result *signal.SignalResult, height, width int) string {
 	return resultStr.String()
 }
 
-func renderFrequencyContent(results []signal.FrequencyResult, maxW int) []string {
+func renderFrequencyContent(results []signal.FrequencyResult, cursor, maxW int) []string {
 	var lines []string
 
 	if len(results) == 0 {
@@ -1861,18 +2713,28 @@ func renderFrequencyContent(results []signal.FrequencyResult, maxW int) []string
 		}
 		msg := r.Message
 		countStr := " x" + Itoa(r.Count)
-		maxMsgW := maxW - len(countStr) - 2
+		badge := LevelStyle(r.Level).Render(PadCenter(r.Level.String(), 5)) + " "
+		maxMsgW := maxW - len(countStr) - lipgloss.Width(badge) - 4
 		if len(msg) > maxMsgW {
 			msg = msg[:maxMsgW-1] + "…"
 		}
-		line := StyleMessage.Render(msg) + StyleBarAccent.Render(countStr)
+		msgStyle := StyleMessage
+		if r.Level == logx.LevelFatal {
+			msgStyle = StyleFatalMessage
+		}
+		marker := "  "
+		if i == cursor {
+			marker = StyleBarAccent.Render("▸ ")
+			msgStyle = StyleSelectedLine
+		}
+		line := marker + badge + msgStyle.Render(msg) + StyleBarAccent.Render(countStr)
 		lines = append(lines, line)
 	}
 
 	return lines
 }
 
-func renderLifetimeContent(r *signal.LifetimeResult, maxW int) []string {
+func renderLifetimeContent(r *signal.LifetimeResult, cursor, maxW int) []string {
 	var lines []string
 
 	if r == nil || r.Message == "" {
@@ -1893,17 +2755,41 @@ func renderLifetimeContent(r *signal.LifetimeResult, maxW int) []string {
 	if r.IsSingle {
 		lines = append(lines, StyleDetailDim.Render("Single occurrence"))
 	} else {
-		lines = append(lines, StyleDetailLabel.Render("First seen: ")+StyleDetailValue.Render(r.FirstSeen))
-		lines = append(lines, StyleDetailLabel.Render("Last seen:  ")+StyleDetailValue.Render(r.LastSeen))
+		for i, row := range [][2]string{{"First seen: ", r.FirstSeen}, {"Last seen:  ", r.LastSeen}} {
+			if i == cursor {
+				lines = append(lines, StyleBarAccent.Render("▸ ")+StyleDetailLabel.Render(row[0])+StyleSelectedLine.Render(row[1]))
+			} else {
+				lines = append(lines, "  "+StyleDetailLabel.Render(row[0])+StyleDetailValue.Render(row[1]))
+			}
+		}
 	}
 
 	lines = append(lines, "")
 	lines = append(lines, StyleDetailLabel.Render("Occurrences: ")+StyleBarAccent.Render(Itoa(r.Occurrences)))
 
+	if r.Timed < 2 {
+		return lines
+	}
+	lines = append(lines, StyleDetailLabel.Render("Span:        ")+StyleDetailValue.Render(signal.FormatDuration(r.Span)))
+	lines = append(lines, StyleDetailLabel.Render("Gaps:        ")+StyleDetailValue.Render("mean "+signal.FormatDuration(r.MeanGap)+" · max "+signal.FormatDuration(r.MaxGap))+
+		StyleDetailDim.Render(" after "+r.MaxGapAt.Format("15:04:05")))
+	pattern := StyleDetailDim.Render(r.Pattern())
+	if r.Periodic {
+		pattern = StyleBarAccent.Render(r.Pattern())
+	}
+	lines = append(lines, StyleDetailLabel.Render("Pattern:     ")+pattern)
+	status := StyleDetailValue.Render(r.Status())
+	if r.Ongoing {
+		status = StyleLevelError.Copy().Background(lipgloss.NoColor{}).Padding(0).Render(r.Status())
+	}
+	lines = append(lines, StyleDetailLabel.Render("Status:      ")+status)
+
 	return lines
 }
 
-func renderBurstContent(r *signal.BurstResult, maxW int) []string {
+const burstListRows = 8
+
+func renderBurstContent(r *signal.BurstResult, cursor, maxW int) []string {
 	var lines []string
 
 	if r == nil || r.Message == "" {
@@ -1925,6 +2811,29 @@ func renderBurstContent(r *signal.BurstResult, maxW int) []string {
 		lines = append(lines, StyleLevelError.Render(" BURST DETECTED "))
 		lines = append(lines, "")
 		lines = append(lines, StyleDetailValue.Render(r.Description))
+		lines = append(lines, "")
+
+		start := 0
+		if cursor >= burstListRows {
+			start = cursor - burstListRows + 1
+		}
+		end := start + burstListRows
+		if end > len(r.Bursts) {
+			end = len(r.Bursts)
+		}
+		for i := start; i < end; i++ {
+			b := r.Bursts[i]
+			span := b.Start.Format("2006-01-02 15:04:05") + " – " + b.End.Format("15:04:05")
+			line := span + "  " + PadLeft(Itoa(b.Count), 5) + " over " + b.Span()
+			if i == cursor {
+				lines = append(lines, StyleBarAccent.Render("▸ ")+StyleSelectedLine.Render(line))
+			} else {
+				lines = append(lines, "  "+StyleDetailValue.Render(line))
+			}
+		}
+		if more := len(r.Bursts) - end; more > 0 {
+			lines = append(lines, StyleDetailDim.Render("  … "+Itoa(more)+" more"))
+		}
 	} else {
 		lines = append(lines, StyleStatus.Render("✓ No abnormal burst detected"))
 		if r.Count > 0 {
@@ -1936,6 +2845,198 @@ func renderBurstContent(r *signal.BurstResult, maxW int) []string {
 	return lines
 }
 
+func renderStatsContent(r *signal.StatsResult, maxW int) []string {
+	var lines []string
+
+	if r == nil {
+		lines = append(lines, StyleEmpty.Render("No data"))
+		return lines
+	}
+
+	title := StyleDetailLabel.Render("FIELD ") + StyleBarAccent.Render(r.Field)
+	if r.GroupBy != "" {
+		title += StyleDetailLabel.Render(" BY ") + StyleBarAccent.Render(r.GroupBy)
+	}
+	lines = append(lines, title)
+	lines = append(lines, "")
+
+	if r.Count == 0 {
+		lines = append(lines, StyleEmpty.Render("No numeric values found"))
+		return lines
+	}
+
+	stat := func(label string, v float64) string {
+		return StyleDetailLabel.Render(label+" ") + StyleDetailValue.Render(PadRight(signal.FormatNumber(v), 10))
+	}
+	lines = append(lines, StyleDetailLabel.Render("count ")+StyleDetailValue.Render(PadRight(Itoa(r.Count), 10))+
+		stat("min", r.Min)+stat("max", r.Max)+stat("mean", r.Mean))
+	lines = append(lines, stat("p50  ", r.P50)+stat("p90", r.P90)+stat("p99", r.P99))
+	if r.Skipped > 0 {
+		lines = append(lines, StyleDetailDim.Render("skipped "+Itoa(r.Skipped)+" non-numeric values"))
+	}
+	lines = append(lines, "")
+
+	maxCount := 0
+	labelW := 0
+	for _, b := range r.Histogram {
+		if b.Count > maxCount {
+			maxCount = b.Count
+		}
+		if w := len(signal.FormatNumber(b.Low)); w > labelW {
+			labelW = w
+		}
+	}
+	countW := len(Itoa(maxCount)) + 1
+	barW := maxW - labelW - countW - 2
+	for _, b := range r.Histogram {
+		n := 0
+		if maxCount > 0 {
+			n = b.Count * barW / maxCount
+		}
+		if n == 0 && b.Count > 0 {
+			n = 1
+		}
+		lines = append(lines, StyleDetailDim.Render(PadLeft(signal.FormatNumber(b.Low), labelW))+" "+
+			StyleBarAccent.Render(strings.Repeat("█", n))+StyleDetailValue.Render(" "+Itoa(b.Count)))
+	}
+
+	if r.GroupBy != "" && len(r.Groups) > 0 {
+		lines = append(lines, "")
+		colW := 9
+		nameW := maxW - colW*5
+		lines = append(lines, StyleDetailLabel.Render(PadRight(TruncateVisual(r.GroupBy, nameW-1), nameW)+
+			PadLeft("count", colW)+PadLeft("p50", colW)+PadLeft("p90", colW)+PadLeft("p99", colW)+PadLeft("max", colW)))
+		for _, g := range r.Groups {
+			lines = append(lines, StyleMessage.Render(PadRight(TruncateVisual(g.Value, nameW-1), nameW))+
+				StyleDetailValue.Render(PadLeft(Itoa(g.Count), colW)+PadLeft(signal.FormatNumber(g.P50), colW)+PadLeft(signal.FormatNumber(g.P90), colW))+
+				StyleBarAccent.Render(PadLeft(signal.FormatNumber(g.P99), colW))+
+				StyleDetailValue.Render(PadLeft(signal.FormatNumber(g.Max), colW)))
+		}
+	}
+
+	return lines
+}
+
+func renderAnomalyContent(r *signal.AnomalyResult, cursor, maxW int) []string {
+	var lines []string
+
+	if r == nil || r.Total == 0 {
+		lines = append(lines, StyleEmpty.Render("No data"))
+		return lines
+	}
+
+	lines = append(lines, StyleDetailLabel.Render("MOST UNUSUAL MESSAGES")+
+		StyleDetailDim.Render("  "+Itoa(r.Templates)+" templates in "+Itoa(r.Total)+" lines"))
+	lines = append(lines, "")
+
+	if len(r.Items) == 0 {
+		lines = append(lines, StyleStatus.Render("✓ No anomalies found"))
+		return lines
+	}
+
+	for i, a := range r.Items {
+		rank := PadLeft(Itoa(i+1), 3) + " "
+		badge := LevelStyle(a.Level).Render(PadCenter(a.Level.String(), 5)) + " "
+		countStr := " x" + Itoa(a.Count)
+		msgW := maxW - len(rank) - lipgloss.Width(badge) - len(countStr)
+		msgStyle := StyleMessage
+		if a.Level == logx.LevelFatal {
+			msgStyle = StyleFatalMessage
+		}
+		rankStr := StyleDetailDim.Render(rank)
+		if i == cursor {
+			rankStr = StyleBarAccent.Render("▸" + rank[1:])
+			msgStyle = StyleSelectedLine
+		}
+		lines = append(lines, rankStr+badge+msgStyle.Render(TruncateVisual(a.Template, msgW))+StyleBarAccent.Render(countStr))
+
+		var reasons []string
+		for _, reason := range a.Reasons {
+			reasons = append(reasons, a.Describe(reason))
+		}
+		lines = append(lines, strings.Repeat(" ", len(rank))+StyleDetailDim.Render(TruncateVisual(strings.Join(reasons, " · "), maxW-len(rank))))
+	}
+
+	if !r.ByTime {
+		lines = append(lines, "")
+		lines = append(lines, StyleDetailDim.Render("Few timestamps, using line order"))
+	}
+
+	return lines
+}
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+func renderRateContent(r *signal.RateResult, maxW int) []string {
+	var lines []string
+
+	if r == nil {
+		lines = append(lines, StyleEmpty.Render("No data"))
+		return lines
+	}
+
+	lines = append(lines, StyleDetailLabel.Render("RATE OF ")+StyleMessage.Render(TruncateVisual(r.Label, maxW-8)))
+	lines = append(lines, "")
+
+	if len(r.Counts) == 0 {
+		lines = append(lines, StyleEmpty.Render("No timestamped events"))
+		return lines
+	}
+
+	counts := r.Counts
+	if len(counts) > maxW {
+		counts = counts[len(counts)-maxW:]
+	}
+	offset := len(r.Counts) - len(counts)
+	cellW := maxW / len(counts)
+	if cellW > 4 {
+		cellW = 4
+	}
+	var spark strings.Builder
+	for i, n := range counts {
+		ch := " "
+		if n > 0 {
+			ch = string(sparkBlocks[(n*(len(sparkBlocks)-1)+r.Peak-1)/r.Peak])
+		}
+		ch = strings.Repeat(ch, cellW)
+		if i+offset == r.PeakIdx {
+			spark.WriteString(StyleLevelError.Copy().Padding(0).Render(ch))
+		} else {
+			spark.WriteString(StyleBarAccent.Render(ch))
+		}
+	}
+	lines = append(lines, spark.String())
+	if peak := r.PeakIdx - offset; peak >= 0 {
+		lines = append(lines, strings.Repeat(" ", peak*cellW+(cellW-1)/2)+StyleLevelError.Copy().Background(lipgloss.NoColor{}).Padding(0).Render("▲"))
+	}
+
+	end := r.Start.Add(time.Duration(len(r.Counts)) * r.Interval)
+	startLabel := r.Start.Add(time.Duration(offset) * r.Interval).Format("15:04:05")
+	endLabel := end.Format("15:04:05")
+	gap := len(counts)*cellW - len(startLabel) - len(endLabel)
+	if gap < 1 {
+		gap = 1
+	}
+	lines = append(lines, StyleDetailDim.Render(startLabel+strings.Repeat(" ", gap)+endLabel))
+	lines = append(lines, "")
+
+	lines = append(lines, StyleDetailLabel.Render("Events:   ")+StyleDetailValue.Render(Itoa(r.Total))+
+		StyleDetailDim.Render("  ("+signal.FormatInterval(r.Interval)+" intervals)"))
+	lines = append(lines, StyleDetailLabel.Render("Peak:     ")+StyleBarAccent.Render(Itoa(r.Peak))+
+		StyleDetailValue.Render(" at "+r.PeakStart().Format("2006-01-02 15:04:05")))
+	ratio := "—"
+	if r.Median > 0 {
+		ratio = strconv.FormatFloat(r.PeakRatio(), 'f', 1, 64) + "×"
+	}
+	lines = append(lines, StyleDetailLabel.Render("Median:   ")+StyleDetailValue.Render(signal.FormatNumber(r.Median)+" per active interval")+
+		StyleDetailDim.Render("  peak ")+StyleBarAccent.Render(ratio))
+	if r.Untimed > 0 {
+		lines = append(lines, StyleDetailDim.Render(Itoa(r.Untimed)+" events without a timestamp"))
+	}
+
+	return lines
+}
+
 func renderDiversityContent(r *signal.DiversityResult, maxW int) []string {
 	var lines []string
 
@@ -1949,6 +3050,9 @@ func renderDiversityContent(r *signal.DiversityResult, maxW int) []string {
 
 	lines = append(lines, StyleDetailLabel.Render("Total ERROR lines:     ")+StyleDetailValue.Render(Itoa(r.TotalErrors)))
 	lines = append(lines, StyleDetailLabel.Render("Unique ERROR messages: ")+StyleDetailValue.Render(Itoa(r.UniqueErrors)))
+	if r.FatalCount > 0 {
+		lines = append(lines, StyleDetailLabel.Render("FATAL lines:           ")+StyleFatalMessage.Render(Itoa(r.FatalCount)))
+	}
 	lines = append(lines, "")
 
 	qualityLabel := "Signal quality: "
@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/kalayciburak/lx/internal/app"
+	"github.com/kalayciburak/lx/internal/input"
+	"github.com/kalayciburak/lx/internal/logx"
+)
+
+func TestListWindowStart(t *testing.T) {
+	tests := []struct {
+		name    string
+		heights []int
+		height  int
+		cursor  int
+		want    int
+	}{
+		{"top fits", []int{1, 1, 1, 1, 1}, 3, 2, 0},
+		{"cursor at the bottom", []int{1, 1, 1, 1, 1}, 3, 4, 2},
+		{"wrapped rows fill the window", []int{2, 3, 1, 4}, 5, 1, 0},
+		{"wrapped row pushes the top out", []int{2, 3, 1, 4}, 5, 2, 1},
+		{"tall cursor row keeps the row above", []int{2, 3, 1, 4}, 5, 3, 2},
+		{"cursor row taller than the window", []int{1, 10}, 5, 1, 1},
+	}
+
+	for _, tt := range tests {
+		got := listWindowStart(tt.cursor, tt.height, func(i int) int { return tt.heights[i] })
+		if got != tt.want {
+			t.Errorf("%s: listWindowStart(%d, %d) = %d, want %d", tt.name, tt.cursor, tt.height, got, tt.want)
+		}
+	}
+}
+
+func TestWrappedRows(t *testing.T) {
+	const width = 80
+	plain := logx.Entry{Level: logx.LevelInfo}
+	_, msgW := messageWidth(listLineParts(&plain, 0, 4, false, false, false, nil), width)
+
+	tests := []struct {
+		name    string
+		message string
+		want    int
+	}{
+		{"fits", strings.Repeat("x", msgW), 1},
+		{"one over", strings.Repeat("x", msgW+1), 2},
+		{"three rows", strings.Repeat("x", 3*msgW), 3},
+		{"wide runes that fit", strings.Repeat("語", msgW/2), 1},
+		{"wide runes one over", strings.Repeat("語", msgW/2+1), 2},
+	}
+
+	for _, tt := range tests {
+		e := plain
+		e.Message = tt.message
+		if got := wrappedRows(&e, 4, width, nil); got != tt.want {
+			t.Errorf("%s: wrappedRows = %d, want %d", tt.name, got, tt.want)
+		}
+		line := RenderListLine(&e, 1, 4, width, true, false, false, false, false, nil, 0, true)
+		if got := strings.Count(line, "\n") + 1; got != tt.want {
+			t.Errorf("%s: rendered %d rows, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestListMessageWidth(t *testing.T) {
+	s := app.NewState(logx.ParseLines([]string{
+		"2024-01-15 10:30:45 INFO " + strings.Repeat("語", 60),
+		"INFO plain",
+	}), input.ModeFile, "test.log")
+
+	withTime := ListMessageWidth(s, 100)
+	s.MoveCursor(1)
+	plain := ListMessageWidth(s, 100)
+	if plain-withTime != 20 {
+		t.Errorf("message width %d with a timestamp, %d without; want 20 apart", withTime, plain)
+	}
+
+	s.MoveCursor(-1)
+	s.ScrollColumns(7, withTime)
+	line := RenderListLine(&s.Entries[0], 1, listLineNumWidth(s), 100, false, false, false, false, false, nil, s.HScroll, false)
+	if w := lipgloss.Width(line); w > 100 || w < 90 || !utf8.ValidString(line) {
+		t.Errorf("scrolled line with wide runes is %d cells (valid %v), want it to fill 100", w, utf8.ValidString(line))
+	}
+}